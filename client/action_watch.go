@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	"google.golang.org/grpc"
+)
+
+const (
+	// WatchSourceWebsocket and WatchSourcePoll name how ActionWatch learns
+	// about new blocks.
+	WatchSourceWebsocket = "websocket"
+	WatchSourcePoll      = "poll"
+
+	watchSubscriber = "lumera-ica-client-watch"
+	watchQuery      = "tm.event='NewBlockHeader'"
+	// watchStaleTimeout is how long the websocket may go without a new block
+	// before the watch falls back to polling.
+	watchStaleTimeout = time.Minute
+)
+
+// ActionTransition is one observed state of a watched action. Height and Time
+// are those of the first block at which the state was seen; when polling that
+// can be a few blocks after the actual change.
+type ActionTransition struct {
+	Action        *types.Action
+	PreviousState types.ActionState
+	Height        int64
+	Time          time.Time
+	Source        string
+}
+
+// ActionWatch follows one action on Lumera. It re-reads the action on every
+// NewBlockHeader event from the CometBFT websocket at RPCEndpoint, and polls
+// the latest block every Interval when the websocket is unavailable.
+type ActionWatch struct {
+	Conn        *grpc.ClientConn
+	RPCEndpoint string
+	Interval    time.Duration
+	// Done reports whether the watch can stop at the action's current state.
+	// An error stops the watch and is returned by Run.
+	Done func(*types.Action) (bool, error)
+	// OnTransition is called for the initial state and every state change.
+	OnTransition func(ActionTransition) error
+	// OnFallback, if set, is called with the reason the watch switched to polling.
+	OnFallback func(error)
+}
+
+// Run watches actionID until Done returns true and returns the final action.
+// It stops with ctx's error when ctx ends first, and with Done's error when
+// Done returns one.
+func (w *ActionWatch) Run(ctx context.Context, actionID string) (*types.Action, error) {
+	if w.Interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive")
+	}
+	state := &watchState{watch: w, actionID: actionID}
+	height, blockTime, err := latestBlock(ctx, w.Conn)
+	if err != nil {
+		return nil, err
+	}
+	if done, err := state.observe(ctx, height, blockTime, WatchSourcePoll); err != nil || done {
+		return state.action, err
+	}
+	err = w.subscribe(ctx, state)
+	if err == nil || ctx.Err() != nil {
+		return state.action, err
+	}
+	var fallback *watchFallbackError
+	if !errors.As(err, &fallback) {
+		return state.action, err
+	}
+	if w.OnFallback != nil {
+		w.OnFallback(fallback.Err)
+	}
+	return state.action, w.poll(ctx, state)
+}
+
+// watchFallbackError marks a websocket failure that polling can recover from.
+type watchFallbackError struct{ Err error }
+
+func (e *watchFallbackError) Error() string { return e.Err.Error() }
+
+// subscribe follows NewBlockHeader events until the action is done. Websocket
+// failures are returned as *watchFallbackError.
+func (w *ActionWatch) subscribe(ctx context.Context, state *watchState) error {
+	if w.RPCEndpoint == "" {
+		return &watchFallbackError{Err: fmt.Errorf("lumera.rpc_endpoint is not set")}
+	}
+	rpc, err := rpchttp.New(w.RPCEndpoint, "/websocket")
+	if err != nil {
+		return &watchFallbackError{Err: fmt.Errorf("rpc client %s: %w", w.RPCEndpoint, err)}
+	}
+	if err := rpc.Start(); err != nil {
+		return &watchFallbackError{Err: fmt.Errorf("websocket %s: %w", w.RPCEndpoint, err)}
+	}
+	defer rpc.Stop() //nolint:errcheck
+	events, err := rpc.Subscribe(ctx, watchSubscriber, watchQuery)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("watch action %s: %w", state.actionID, ctx.Err())
+		}
+		return &watchFallbackError{Err: fmt.Errorf("subscribe %s: %w", w.RPCEndpoint, err)}
+	}
+	defer rpc.Unsubscribe(context.Background(), watchSubscriber, watchQuery) //nolint:errcheck
+
+	// Catch a change made between the initial read and the subscription.
+	if done, err := state.observeLatest(ctx, WatchSourceWebsocket); err != nil || done {
+		return err
+	}
+	stale := time.NewTimer(watchStaleTimeout)
+	defer stale.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("watch action %s: %w", state.actionID, ctx.Err())
+		case <-stale.C:
+			return &watchFallbackError{Err: fmt.Errorf("websocket %s: no new block for %s", w.RPCEndpoint, watchStaleTimeout)}
+		case ev, ok := <-events:
+			if !ok {
+				return &watchFallbackError{Err: fmt.Errorf("websocket %s closed", w.RPCEndpoint)}
+			}
+			header, ok := ev.Data.(cmttypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			stale.Reset(watchStaleTimeout)
+			if done, err := state.observe(ctx, header.Header.Height, header.Header.Time, WatchSourceWebsocket); err != nil || done {
+				return err
+			}
+		}
+	}
+}
+
+// poll re-reads the latest block and the action every Interval until done.
+func (w *ActionWatch) poll(ctx context.Context, state *watchState) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("watch action %s: %w", state.actionID, ctx.Err())
+		case <-ticker.C:
+		}
+		if done, err := state.observeLatest(ctx, WatchSourcePoll); err != nil || done {
+			return err
+		}
+	}
+}
+
+// watchState holds the last seen action and reports changes to it.
+type watchState struct {
+	watch    *ActionWatch
+	actionID string
+	action   *types.Action
+	height   int64
+}
+
+// observeLatest reads the action at the latest block.
+func (s *watchState) observeLatest(ctx context.Context, source string) (bool, error) {
+	height, blockTime, err := latestBlock(ctx, s.watch.Conn)
+	if err != nil {
+		return false, err
+	}
+	return s.observe(ctx, height, blockTime, source)
+}
+
+// observe reads the action after the block at height and reports a change.
+func (s *watchState) observe(ctx context.Context, height int64, blockTime time.Time, source string) (bool, error) {
+	if height <= s.height {
+		return false, nil
+	}
+	resp, err := actiontypes.NewQueryClient(s.watch.Conn).GetAction(ctx, &actiontypes.QueryGetActionRequest{ActionID: s.actionID})
+	if err != nil {
+		return false, fmt.Errorf("get action %s: %w", s.actionID, err)
+	}
+	action := types.ActionFromProto(resp.GetAction())
+	s.height = height
+	if s.action == nil || action.State != s.action.State {
+		transition := ActionTransition{Action: action, Height: height, Time: blockTime, Source: source}
+		if s.action != nil {
+			transition.PreviousState = s.action.State
+		}
+		s.action = action
+		if err := s.watch.OnTransition(transition); err != nil {
+			return false, err
+		}
+	}
+	return s.watch.Done(action)
+}
+
+// latestBlock returns the height and time of the latest Lumera block.
+func latestBlock(ctx context.Context, conn *grpc.ClientConn) (int64, time.Time, error) {
+	resp, err := cmtservice.NewServiceClient(conn).GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("get latest block: %w", err)
+	}
+	header := resp.GetSdkBlock().GetHeader()
+	return header.Height, header.Time, nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc"
+)
+
+// ActionFilter selects actions by state, type and block height. Empty fields
+// and zero heights match everything; heights are inclusive.
+type ActionFilter struct {
+	State     string
+	Type      string
+	MinHeight int64
+	MaxHeight int64
+}
+
+// Match reports whether action passes the filter.
+func (f ActionFilter) Match(action *types.Action) bool {
+	switch {
+	case f.State != "" && string(action.State) != f.State:
+		return false
+	case f.Type != "" && string(action.Type) != f.Type:
+		return false
+	case f.MinHeight > 0 && action.BlockHeight < f.MinHeight:
+		return false
+	case f.MaxHeight > 0 && action.BlockHeight > f.MaxHeight:
+		return false
+	}
+	return true
+}
+
+// ParseActionState normalizes a state name such as "done" or
+// "ACTION_STATE_DONE" to its enum name.
+func ParseActionState(s string) (string, error) {
+	return parseActionEnum(s, "ACTION_STATE_", actiontypes.ActionState_value)
+}
+
+// ParseActionType normalizes a type name such as "cascade" or
+// "ACTION_TYPE_CASCADE" to its enum name.
+func ParseActionType(s string) (string, error) {
+	return parseActionEnum(s, "ACTION_TYPE_", actiontypes.ActionType_value)
+}
+
+func parseActionEnum(s, prefix string, values map[string]int32) (string, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	// Zero is the UNSPECIFIED value, which no action has.
+	if values[name] == 0 {
+		names := make([]string, 0, len(values))
+		for n, v := range values {
+			if v != 0 {
+				names = append(names, strings.TrimPrefix(n, prefix))
+			}
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unknown value %q (one of: %s)", s, strings.Join(names, ", "))
+	}
+	return name, nil
+}
+
+// ActionPage is one page of ListActionsByCreator. Actions holds the matches
+// among Scanned actions; NextKey is empty on the last page.
+type ActionPage struct {
+	Actions []*types.Action
+	Scanned int
+	NextKey []byte
+}
+
+// ListActionsByCreator fetches one page of up to limit actions created by
+// creator, starting at key (nil for the first page), and keeps those matching
+// filter. The action module has no state/type/height filters for this query,
+// so they are applied client-side.
+func ListActionsByCreator(ctx context.Context, conn *grpc.ClientConn, creator string, filter ActionFilter, key []byte, limit uint64) (*ActionPage, error) {
+	resp, err := actiontypes.NewQueryClient(conn).ListActionsByCreator(ctx, &actiontypes.QueryListActionsByCreatorRequest{
+		Creator:    creator,
+		Pagination: &query.PageRequest{Key: key, Limit: limit},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list actions by creator %s: %w", creator, err)
+	}
+	page := &ActionPage{Scanned: len(resp.GetActions()), NextKey: resp.GetPagination().GetNextKey()}
+	for _, pb := range resp.GetActions() {
+		if action := types.ActionFromProto(pb); filter.Match(action) {
+			page.Actions = append(page.Actions, action)
+		}
+	}
+	return page, nil
+}
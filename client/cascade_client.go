@@ -3,8 +3,6 @@ package client
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -18,14 +16,17 @@ const defaultCascadeTimeout = 30 * time.Second
 
 // Client bundles the cascade client with its backing keyring and owner address.
 // The keyring is the controller chain keyring; the Lumera address is derived from it.
+// AppPubkey is the app key's public key, registered as the action app_pubkey.
 type Client struct {
 	Cascade      *cascade.Client
 	Keyring      keyring.Keyring
 	OwnerAddress string
+	AppPubkey    []byte
 }
 
 // NewCascadeClient initializes the SDK cascade client using controller keyring settings.
-// It derives a Lumera bech32 address from the same key name for action registration.
+// It derives a Lumera bech32 address from the same key name for action registration,
+// and signs Cascade metadata with the app key (controller.key_name unless [app_key] is set).
 func NewCascadeClient(ctx context.Context, cfg *Config) (*Client, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("config is nil")
@@ -36,11 +37,27 @@ func NewCascadeClient(ctx context.Context, cfg *Config) (*Client, error) {
 		return nil, err
 	}
 	// Validate that keys in the keyring match the configured key types.
-	if err := validateKeyType(controllerKR, cfg.Controller.KeyName, cfg.Controller.KeyType); err != nil {
-		return nil, fmt.Errorf("controller key type: %w", err)
+	if err := ValidateKeys(controllerKR, cfg); err != nil {
+		return nil, err
+	}
+	// Resolve the app key; a separate keyring is overlaid for the cascade SDK only.
+	appKR, err := OpenAppKeyring(cfg, controllerKR)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateAppKey(appKR, cfg); err != nil {
+		return nil, err
 	}
-	if err := validateKeyType(controllerKR, cfg.Lumera.KeyName, cfg.Lumera.KeyType); err != nil {
-		return nil, fmt.Errorf("lumera key type: %w", err)
+	appPubkey, err := AppPubkey(appKR, cfg.AppKeyName())
+	if err != nil {
+		return nil, fmt.Errorf("app key: %w", err)
+	}
+	cascadeKR := controllerKR
+	if cfg.AppKey.Enabled() && cfg.AppKey.SeparateKeyring() {
+		cascadeKR, err = newAppKeyring(controllerKR, appKR, cfg.AppKey.KeyName)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// Resolve controller owner address using the configured controller account HRP.
 	ownerAddr, err := sdkcrypto.AddressFromKey(controllerKR, cfg.Controller.KeyName, cfg.Controller.AccountHRP)
@@ -48,25 +65,26 @@ func NewCascadeClient(ctx context.Context, cfg *Config) (*Client, error) {
 		return nil, fmt.Errorf("derive controller address: %w", err)
 	}
 	// Resolve Lumera address with the Lumera HRP for on-chain action registration.
-	lumeraAddr, err := sdkcrypto.AddressFromKey(controllerKR, cfg.Lumera.KeyName, "lumera")
+	lumeraAddr, err := sdkcrypto.AddressFromKey(controllerKR, cfg.Lumera.KeyName, LumeraHRP)
 	if err != nil {
 		return nil, fmt.Errorf("derive lumera address: %w", err)
 	}
 	// Initialize cascade SDK client with Lumera connection settings and log level.
+	// ICA metadata and download signatures use the ICA owner key, i.e. the app key.
 	casc, err := cascade.New(ctx, cascade.Config{
 		ChainID:         cfg.Lumera.ChainID,
 		GRPCAddr:        cfg.Lumera.GRPCEndpoint,
 		Address:         lumeraAddr,
 		KeyName:         cfg.Lumera.KeyName,
-		ICAOwnerKeyName: cfg.Controller.KeyName,
+		ICAOwnerKeyName: cfg.AppKeyName(),
 		ICAOwnerHRP:     cfg.Controller.AccountHRP,
 		Timeout:         defaultCascadeTimeout,
 		LogLevel:        cfg.Lumera.LogLevel,
-	}, controllerKR)
+	}, cascadeKR)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{Cascade: casc, Keyring: controllerKR, OwnerAddress: ownerAddr}, nil
+	return &Client{Cascade: casc, Keyring: controllerKR, OwnerAddress: ownerAddr, AppPubkey: appPubkey}, nil
 }
 
 // validateKeyType checks that a key in the keyring uses the algorithm matching
@@ -79,7 +97,7 @@ func validateKeyType(kr keyring.Keyring, keyName, configuredType string) error {
 	}
 	rec, err := kr.Key(keyName)
 	if err != nil {
-		return fmt.Errorf("key %q not found in keyring: %w", keyName, err)
+		return fmt.Errorf("%w: %q: %w", ErrKeyNotFound, keyName, err)
 	}
 	pub, err := rec.GetPubKey()
 	if err != nil {
@@ -87,65 +105,42 @@ func validateKeyType(kr keyring.Keyring, keyName, configuredType string) error {
 	}
 	expected := string(kt.SigningAlgo().Name())
 	if actual := pub.Type(); actual != expected {
-		return fmt.Errorf("key %q type mismatch: config expects %s but keyring has %s", keyName, expected, actual)
+		return &KeyTypeMismatchError{KeyName: keyName, Expected: expected, Actual: actual}
 	}
 	return nil
 }
 
+// KeyTypeMismatchError reports a keyring key whose algorithm differs from the configured key_type.
+type KeyTypeMismatchError struct {
+	KeyName  string
+	Expected string
+	Actual   string
+}
+
+func (e *KeyTypeMismatchError) Error() string {
+	return fmt.Sprintf("key %q type mismatch: config expects %s but keyring has %s", e.KeyName, e.Expected, e.Actual)
+}
+
+func (e *KeyTypeMismatchError) Is(target error) bool { return target == ErrKeyTypeMismatch }
+
 // newControllerKeyring constructs the Cosmos keyring for the controller chain.
+// With the remote signer it returns a keyring backed by the remote keys.
 func newControllerKeyring(cfg ControllerConfig) (keyring.Keyring, error) {
-	passphrase, err := resolvePassphrase(cfg.KeyringPassphrasePlain, cfg.KeyringPassphraseFile)
-	if err != nil {
-		return nil, err
+	if cfg.RemoteSigner() {
+		signer, err := NewRemoteSigner(cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultSignerTimeout)
+		defer cancel()
+		return NewSignerKeyring(ctx, signer)
 	}
 	// For test backend, fall back to controller.home when keyring_dir is unset.
 	dir := strings.TrimSpace(cfg.KeyringDir)
 	if dir == "" && strings.EqualFold(cfg.KeyringBackend, "test") {
 		dir = strings.TrimSpace(cfg.Home)
 	}
-	params := sdkcrypto.KeyringParams{
-		AppName: keyringAppName(cfg),
-		Backend: cfg.KeyringBackend,
-		Dir:     dir,
-		Input:   passphraseReader(passphrase),
-	}
-	kr, err := sdkcrypto.NewKeyring(params)
-	if err != nil {
-		return nil, fmt.Errorf("init keyring: %w", err)
-	}
-	return kr, nil
-}
-
-// resolvePassphrase selects a single passphrase source or returns empty for interactive prompts.
-func resolvePassphrase(plain, filePath string) (string, error) {
-	plain = strings.TrimSpace(plain)
-	filePath = strings.TrimSpace(filePath)
-	if plain != "" && filePath != "" {
-		return "", fmt.Errorf("only one of keyring passphrase plain/file may be set")
-	}
-	if plain != "" {
-		return plain, nil
-	}
-	if filePath == "" {
-		return "", nil
-	}
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", fmt.Errorf("read keyring passphrase file: %w", err)
-	}
-	pass := strings.TrimSpace(string(data))
-	if pass == "" {
-		return "", fmt.Errorf("keyring passphrase file is empty")
-	}
-	return pass, nil
-}
-
-func passphraseReader(passphrase string) io.Reader {
-	if passphrase == "" {
-		return nil
-	}
-	// Repeat the passphrase to satisfy multiple keyring prompts.
-	return &repeatReader{data: []byte(passphrase + "\n")}
+	return openKeyring(keyringAppName(cfg), cfg.KeyringBackend, dir, cfg.passphraseSource())
 }
 
 // keyringAppName selects a stable keyring application name for the controller chain.
@@ -158,31 +153,3 @@ func keyringAppName(cfg ControllerConfig) string {
 	}
 	return "lumera"
 }
-
-type repeatReader struct {
-	data []byte
-	pos  int
-}
-
-// Read loops the underlying data to satisfy repeated keyring reads.
-func (r *repeatReader) Read(p []byte) (int, error) {
-	if len(r.data) == 0 {
-		return 0, io.EOF
-	}
-	n := 0
-	for n < len(p) {
-		remaining := len(r.data) - r.pos
-		if remaining == 0 {
-			r.pos = 0
-			remaining = len(r.data)
-		}
-		chunk := remaining
-		if chunk > len(p)-n {
-			chunk = len(p) - n
-		}
-		copy(p[n:n+chunk], r.data[r.pos:r.pos+chunk])
-		n += chunk
-		r.pos += chunk
-	}
-	return n, nil
-}
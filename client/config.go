@@ -3,20 +3,35 @@ package client
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
-
-	"github.com/BurntSushi/toml"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // Config is the root configuration for the ICA reference client.
 // It separates Lumera chain settings from the controller chain/keyring settings.
+// Profiles holds named overrides selected with LoadOptions.Profile.
 type Config struct {
+	Lumera     LumeraConfig             `toml:"lumera"`
+	Controller ControllerConfig         `toml:"controller"`
+	AppKey     AppKeyConfig             `toml:"app_key"`
+	Funding    FundingConfig            `toml:"funding"`
+	Profiles   map[string]ProfileConfig `toml:"profiles"`
+}
+
+// ProfileConfig is a [profiles.<name>] block: one controller chain/ICA, plus
+// optional Lumera and funding settings. Fields it leaves out are inherited
+// from the top-level sections.
+type ProfileConfig struct {
 	Lumera     LumeraConfig     `toml:"lumera"`
 	Controller ControllerConfig `toml:"controller"`
+	AppKey     AppKeyConfig     `toml:"app_key"`
+	Funding    FundingConfig    `toml:"funding"`
 }
 
 // LumeraConfig stores the host chain connection settings.
@@ -32,6 +47,8 @@ type LumeraConfig struct {
 
 // ControllerConfig stores controller chain and keyring settings.
 // The keyring is used for ICA signing and cascade metadata signatures.
+// With Signer = "remote" the keys are served by the remote signer at
+// SignerEndpoint instead and the keyring settings are ignored.
 type ControllerConfig struct {
 	ChainID                  string `toml:"chain_id"`
 	GRPCEndpoint             string `toml:"grpc_endpoint"`
@@ -42,27 +59,111 @@ type ControllerConfig struct {
 	KeyType                  string `toml:"key_type"`
 	KeyringBackend           string `toml:"keyring_backend"`
 	KeyringDir               string `toml:"keyring_dir"`
-	KeyringPassphrasePlain   string `toml:"keyring_passphrase_plain"`
+	KeyringPassphrasePlain   string `toml:"keyring_passphrase_plain" secret:"true"`
 	KeyringPassphraseFile    string `toml:"keyring_passphrase_file"`
+	KeyringPassphraseEnv     string `toml:"keyring_passphrase_env"`
+	KeyringPassphraseCommand string `toml:"keyring_passphrase_command"`
 	GasPrices                string `toml:"gas_prices"`
 	AccountHRP               string `toml:"account_hrp"`
 	ConnectionID             string `toml:"connection_id"`
 	CounterpartyConnectionID string `toml:"counterparty_connection_id"`
+	PacketTimeout            string `toml:"packet_timeout"`
+	AckWaitTimeout           string `toml:"ack_wait_timeout"`
+	Signer                   string `toml:"signer"`
+	SignerEndpoint           string `toml:"signer_endpoint"`
+	SignerTLSCAFile          string `toml:"signer_tls_ca_file"`
+	SignerTLSCertFile        string `toml:"signer_tls_cert_file"`
+	SignerTLSKeyFile         string `toml:"signer_tls_key_file"`
+	SignerTLSServerName      string `toml:"signer_tls_server_name"`
+
+	// stdinPassphrase is the passphrase given with --passphrase-stdin (LoadOptions.Passphrase).
+	stdinPassphrase []byte
 }
 
-// LoadConfig reads a TOML config file, expands paths, and validates the result.
-func LoadConfig(path string) (*Config, error) {
-	var cfg Config
-	if _, err := toml.DecodeFile(path, &cfg); err != nil {
-		return nil, fmt.Errorf("decode config: %w", err)
+// SetStdinPassphrase sets the passphrase given with --passphrase-stdin on a
+// config built without LoadConfigWithOptions. It is not copied.
+func (c *ControllerConfig) SetStdinPassphrase(pass []byte) {
+	c.stdinPassphrase = pass
+}
+
+// RemoteSigner reports whether keys are served by a remote signer.
+func (c ControllerConfig) RemoteSigner() bool {
+	return c.Signer == SignerRemote
+}
+
+// ICATimeouts parses packet_timeout and ack_wait_timeout (Go durations).
+// Empty values default to the ICA packet timeout for both.
+func (c ControllerConfig) ICATimeouts() (time.Duration, time.Duration, error) {
+	packetTimeout, err := parseTimeout(c.PacketTimeout, defaultICAPacketTimeout)
+	if err != nil {
+		return 0, 0, wrapConfigError("controller.packet_timeout", err)
 	}
-	if err := cfg.ExpandPaths(); err != nil {
-		return nil, err
+	ackWaitTimeout, err := parseTimeout(c.AckWaitTimeout, packetTimeout)
+	if err != nil {
+		return 0, 0, wrapConfigError("controller.ack_wait_timeout", err)
+	}
+	return packetTimeout, ackWaitTimeout, nil
+}
+
+// AppKeyConfig selects an application key distinct from the controller key.
+// Its pubkey is registered as the action app_pubkey and it signs Cascade
+// metadata; MsgSendTx is still signed by the controller key. The key is looked
+// up in the controller keyring unless KeyringBackend selects its own keyring.
+// The section is disabled when KeyName is empty.
+type AppKeyConfig struct {
+	KeyName                  string `toml:"key_name"`
+	KeyType                  string `toml:"key_type"`
+	KeyringBackend           string `toml:"keyring_backend"`
+	KeyringDir               string `toml:"keyring_dir"`
+	KeyringPassphrasePlain   string `toml:"keyring_passphrase_plain" secret:"true"`
+	KeyringPassphraseFile    string `toml:"keyring_passphrase_file"`
+	KeyringPassphraseEnv     string `toml:"keyring_passphrase_env"`
+	KeyringPassphraseCommand string `toml:"keyring_passphrase_command"`
+}
+
+// Enabled reports whether a separate app key is configured.
+func (a AppKeyConfig) Enabled() bool {
+	return strings.TrimSpace(a.KeyName) != ""
+}
+
+// SeparateKeyring reports whether the app key lives outside the controller keyring.
+func (a AppKeyConfig) SeparateKeyring() bool {
+	return strings.TrimSpace(a.KeyringBackend) != ""
+}
+
+// FundingConfig controls automatic ICS-20 top-ups of the ICA on Lumera.
+// Balances are Lumera-side coins; SourceDenom is the controller-side denom sent
+// 1:1 by the transfer. The policy is disabled when MinBalance is empty.
+type FundingConfig struct {
+	MinBalance      string `toml:"min_balance"`
+	TargetBalance   string `toml:"target_balance"`
+	SourceDenom     string `toml:"source_denom"`
+	TransferChannel string `toml:"transfer_channel"`
+}
+
+// Enabled reports whether the automatic top-up policy is configured.
+func (f FundingConfig) Enabled() bool {
+	return strings.TrimSpace(f.MinBalance) != ""
+}
+
+// Thresholds parses the configured minimum and target balances.
+func (f FundingConfig) Thresholds() (sdk.Coin, sdk.Coin, error) {
+	minBalance, err := sdk.ParseCoinNormalized(strings.TrimSpace(f.MinBalance))
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, wrapConfigError("funding.min_balance", err)
 	}
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	targetBalance, err := sdk.ParseCoinNormalized(strings.TrimSpace(f.TargetBalance))
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, wrapConfigError("funding.target_balance", err)
 	}
-	return &cfg, nil
+	return minBalance, targetBalance, nil
+}
+
+// LoadConfig reads a TOML, YAML or JSON config file over the built-in defaults, expands paths,
+// and validates the result. Use LoadConfigWithOptions for env/flag overrides.
+func LoadConfig(path string) (*Config, error) {
+	cfg, _, err := LoadConfigWithOptions(path, LoadOptions{})
+	return cfg, err
 }
 
 // ExpandPaths expands tilde-prefixed values in place.
@@ -71,15 +172,32 @@ func (c *Config) ExpandPaths() error {
 	var err error
 	c.Controller.Home, err = expandHome(c.Controller.Home)
 	if err != nil {
-		return fmt.Errorf("expand controller.home: %w", err)
+		return wrapConfigError("controller.home", fmt.Errorf("expand: %w", err))
 	}
 	c.Controller.KeyringDir, err = expandHome(c.Controller.KeyringDir)
 	if err != nil {
-		return fmt.Errorf("expand controller.keyring_dir: %w", err)
+		return wrapConfigError("controller.keyring_dir", fmt.Errorf("expand: %w", err))
 	}
 	c.Controller.KeyringPassphraseFile, err = expandHome(c.Controller.KeyringPassphraseFile)
 	if err != nil {
-		return fmt.Errorf("expand controller.keyring_passphrase_file: %w", err)
+		return wrapConfigError("controller.keyring_passphrase_file", fmt.Errorf("expand: %w", err))
+	}
+	for field, value := range map[string]*string{
+		"controller.signer_tls_ca_file":   &c.Controller.SignerTLSCAFile,
+		"controller.signer_tls_cert_file": &c.Controller.SignerTLSCertFile,
+		"controller.signer_tls_key_file":  &c.Controller.SignerTLSKeyFile,
+	} {
+		if *value, err = expandHome(*value); err != nil {
+			return wrapConfigError(field, fmt.Errorf("expand: %w", err))
+		}
+	}
+	c.AppKey.KeyringDir, err = expandHome(c.AppKey.KeyringDir)
+	if err != nil {
+		return wrapConfigError("app_key.keyring_dir", fmt.Errorf("expand: %w", err))
+	}
+	c.AppKey.KeyringPassphraseFile, err = expandHome(c.AppKey.KeyringPassphraseFile)
+	if err != nil {
+		return wrapConfigError("app_key.keyring_passphrase_file", fmt.Errorf("expand: %w", err))
 	}
 	return nil
 }
@@ -94,73 +212,212 @@ func (c *Config) Validate() error {
 	c.Lumera.LogLevel = logLevel
 	lumeraKeyType, err := normalizeKeyType(c.Lumera.KeyType)
 	if err != nil {
-		return fmt.Errorf("lumera.%w", err)
+		return wrapConfigError("lumera.key_type", err)
 	}
 	c.Lumera.KeyType = lumeraKeyType
 	controllerKeyType, err := normalizeKeyType(c.Controller.KeyType)
 	if err != nil {
-		return fmt.Errorf("controller.%w", err)
+		return wrapConfigError("controller.key_type", err)
 	}
 	c.Controller.KeyType = controllerKeyType
 	if strings.TrimSpace(c.Lumera.ChainID) == "" {
-		return fmt.Errorf("lumera.chain_id is required")
+		return configError("lumera.chain_id", "is required")
 	}
 	if strings.TrimSpace(c.Lumera.GRPCEndpoint) == "" {
-		return fmt.Errorf("lumera.grpc_endpoint is required")
+		return configError("lumera.grpc_endpoint", "is required")
 	}
 	if strings.TrimSpace(c.Lumera.RPCEndpoint) == "" {
-		return fmt.Errorf("lumera.rpc_endpoint is required")
+		return configError("lumera.rpc_endpoint", "is required")
 	}
 	if strings.TrimSpace(c.Lumera.KeyName) == "" {
-		return fmt.Errorf("lumera.key_name is required")
+		return configError("lumera.key_name", "is required")
 	}
 	if strings.TrimSpace(c.Controller.ChainID) == "" {
-		return fmt.Errorf("controller.chain_id is required")
+		return configError("controller.chain_id", "is required")
 	}
 	if strings.TrimSpace(c.Controller.GRPCEndpoint) == "" {
-		return fmt.Errorf("controller.grpc_endpoint is required")
+		return configError("controller.grpc_endpoint", "is required")
 	}
 	if strings.TrimSpace(c.Controller.RPCEndpoint) == "" {
-		return fmt.Errorf("controller.rpc_endpoint is required")
+		return configError("controller.rpc_endpoint", "is required")
 	}
 	if strings.TrimSpace(c.Controller.KeyName) == "" {
-		return fmt.Errorf("controller.key_name is required")
-	}
-	if strings.TrimSpace(c.Controller.KeyringBackend) == "" {
-		return fmt.Errorf("controller.keyring_backend is required")
+		return configError("controller.key_name", "is required")
 	}
 	if strings.TrimSpace(c.Controller.AccountHRP) == "" {
-		return fmt.Errorf("controller.account_hrp is required")
+		return configError("controller.account_hrp", "is required")
 	}
 	if strings.TrimSpace(c.Controller.ConnectionID) == "" {
-		return fmt.Errorf("controller.connection_id is required")
+		return configError("controller.connection_id", "is required")
+	}
+	if err := c.Controller.validateSigner(); err != nil {
+		return err
 	}
-	if strings.TrimSpace(c.Controller.KeyringPassphrasePlain) != "" &&
-		strings.TrimSpace(c.Controller.KeyringPassphraseFile) != "" {
-		return fmt.Errorf("only one of controller.keyring_passphrase_plain or controller.keyring_passphrase_file may be set")
+	// A remote signer holds the keys; the keyring settings are ignored.
+	if !c.Controller.RemoteSigner() {
+		if err := c.Controller.validateKeyring(); err != nil {
+			return err
+		}
+	}
+	if _, _, err := c.Controller.ICATimeouts(); err != nil {
+		return err
+	}
+	if err := c.AppKey.validate(); err != nil {
+		return err
+	}
+	if err := c.Funding.validate(); err != nil {
+		return err
+	}
+	for name := range c.Profiles {
+		if !validProfileName(name) {
+			return configError("profiles."+name, "must use only letters, digits, '-' and '_'")
+		}
 	}
-	backend := strings.ToLower(strings.TrimSpace(c.Controller.KeyringBackend))
+	return nil
+}
+
+// validateKeyring checks and normalizes the local keyring settings.
+func (c *ControllerConfig) validateKeyring() error {
+	if strings.TrimSpace(c.KeyringBackend) == "" {
+		return configError("controller.keyring_backend", "is required")
+	}
+	if err := c.passphraseSource().validate(); err != nil {
+		return err
+	}
+	backend := strings.ToLower(strings.TrimSpace(c.KeyringBackend))
 	switch backend {
 	case "os", "file", "test":
-		c.Controller.KeyringBackend = backend
+		c.KeyringBackend = backend
 	default:
-		return fmt.Errorf("controller.keyring_backend must be one of: os, file, test")
+		return configError("controller.keyring_backend", "must be one of: os, file, test")
 	}
-	if backend == "file" && strings.TrimSpace(c.Controller.KeyringDir) == "" {
-		return fmt.Errorf("controller.keyring_dir is required for file backend")
+	if backend == "file" && strings.TrimSpace(c.KeyringDir) == "" {
+		return configError("controller.keyring_dir", "is required for file backend")
 	}
-	if strings.TrimSpace(c.Controller.KeyringPassphraseFile) != "" {
-		b, err := os.ReadFile(c.Controller.KeyringPassphraseFile)
-		if err != nil {
-			return fmt.Errorf("read controller.keyring_passphrase_file: %w", err)
+	return nil
+}
+
+// validateSigner checks and normalizes the signer selection and remote signer settings.
+func (c *ControllerConfig) validateSigner() error {
+	signer := strings.ToLower(strings.TrimSpace(c.Signer))
+	switch signer {
+	case "", SignerKeyring:
+		c.Signer = SignerKeyring
+		return nil
+	case SignerRemote:
+		c.Signer = signer
+	default:
+		return configError("controller.signer", "must be one of: keyring, remote")
+	}
+	endpoint := strings.TrimSpace(c.SignerEndpoint)
+	if endpoint == "" {
+		return configError("controller.signer_endpoint", "is required for the remote signer")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return wrapConfigError("controller.signer_endpoint", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return configError("controller.signer_endpoint", "must be an http:// or https:// URL")
+	}
+	tlsSet := c.SignerTLSCAFile != "" || c.SignerTLSCertFile != "" || c.SignerTLSKeyFile != "" || c.SignerTLSServerName != ""
+	if tlsSet && u.Scheme != "https" {
+		return configError("controller.signer_endpoint", "must use https when signer TLS settings are set")
+	}
+	if (c.SignerTLSCertFile == "") != (c.SignerTLSKeyFile == "") {
+		return configError("controller.signer_tls_cert_file", "and controller.signer_tls_key_file must be set together")
+	}
+	return nil
+}
+
+// validate checks and normalizes the app key section when it is used.
+func (a *AppKeyConfig) validate() error {
+	if !a.Enabled() {
+		if *a != (AppKeyConfig{}) {
+			return configError("app_key.key_name", "is required when [app_key] is set")
 		}
-		if strings.TrimSpace(string(b)) == "" {
-			return fmt.Errorf("controller.keyring_passphrase_file is empty")
+		return nil
+	}
+	keyType, err := normalizeKeyType(a.KeyType)
+	if err != nil {
+		return wrapConfigError("app_key.key_type", err)
+	}
+	a.KeyType = keyType
+	if !a.SeparateKeyring() {
+		if a.KeyringDir != "" || len(a.passphraseSource().set()) > 0 {
+			return configError("app_key.keyring_backend", "is required when other app_key keyring settings are set")
 		}
+		return nil
+	}
+	backend := strings.ToLower(strings.TrimSpace(a.KeyringBackend))
+	switch backend {
+	case "os", "file", "test":
+		a.KeyringBackend = backend
+	default:
+		return configError("app_key.keyring_backend", "must be one of: os, file, test")
+	}
+	if backend != "os" && strings.TrimSpace(a.KeyringDir) == "" {
+		return configError("app_key.keyring_dir", "is required for "+backend+" backend")
+	}
+	return a.passphraseSource().validate()
+}
+
+// validate checks the funding policy when it is enabled.
+func (f FundingConfig) validate() error {
+	if !f.Enabled() {
+		if strings.TrimSpace(f.TargetBalance) != "" {
+			return configError("funding.min_balance", "is required when funding.target_balance is set")
+		}
+		return nil
+	}
+	minBalance, targetBalance, err := f.Thresholds()
+	if err != nil {
+		return err
+	}
+	if minBalance.Denom != targetBalance.Denom {
+		return configError("funding.target_balance", "must use the same denom as funding.min_balance")
+	}
+	if targetBalance.Amount.LT(minBalance.Amount) {
+		return configError("funding.target_balance", "must be >= funding.min_balance")
+	}
+	if strings.TrimSpace(f.SourceDenom) == "" {
+		return configError("funding.source_denom", "is required when funding is enabled")
+	}
+	if err := sdk.ValidateDenom(strings.TrimSpace(f.SourceDenom)); err != nil {
+		return wrapConfigError("funding.source_denom", err)
 	}
 	return nil
 }
 
+// validProfileName reports whether a profile name is safe to use in file names.
+func validProfileName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTimeout parses a positive duration, returning fallback for empty values.
+func parseTimeout(value string, fallback time.Duration) (time.Duration, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(trimmed)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive (got %q)", value)
+	}
+	return d, nil
+}
+
 // ParseKeyType converts a config string to sdkcrypto.KeyType.
 // It defaults to KeyTypeCosmos when the value is empty.
 func ParseKeyType(value string) (sdkcrypto.KeyType, error) {
@@ -196,7 +453,7 @@ func normalizeLogLevel(value string) (string, error) {
 	case "warning":
 		return "warn", nil
 	default:
-		return "", fmt.Errorf("lumera.log_level must be one of: debug, info, warn, error")
+		return "", configError("lumera.log_level", "must be one of: debug, info, warn, error")
 	}
 }
 
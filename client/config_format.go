@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat is a config file encoding, selected by file extension.
+type ConfigFormat string
+
+const (
+	FormatTOML ConfigFormat = "toml"
+	FormatYAML ConfigFormat = "yaml"
+	FormatJSON ConfigFormat = "json"
+)
+
+// ConfigFormatFromPath maps .toml, .yaml/.yml and .json extensions to a format.
+func ConfigFormatFromPath(path string) (ConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", &ConfigError{Reason: fmt.Sprintf("config file %s must end in .toml, .yaml, .yml or .json", path)}
+	}
+}
+
+// decodeConfigFile decodes a TOML, YAML or JSON config file into cfg using the
+// TOML field names for every format, and rejects keys Config does not define.
+// YAML and JSON documents are normalized to TOML so all formats share one decoder.
+func decodeConfigFile(path string, cfg *Config) (toml.MetaData, error) {
+	format, err := ConfigFormatFromPath(path)
+	if err != nil {
+		return toml.MetaData{}, err
+	}
+	var md toml.MetaData
+	if format == FormatTOML {
+		md, err = toml.DecodeFile(path, cfg)
+	} else {
+		var doc map[string]any
+		doc, err = readConfigDocument(path, format)
+		if err != nil {
+			return toml.MetaData{}, err
+		}
+		var buf bytes.Buffer
+		if err = toml.NewEncoder(&buf).Encode(doc); err != nil {
+			return toml.MetaData{}, &ConfigError{Err: fmt.Errorf("decode config: %w", err)}
+		}
+		md, err = toml.Decode(buf.String(), cfg)
+	}
+	if err != nil {
+		return toml.MetaData{}, &ConfigError{Err: fmt.Errorf("decode config: %w", err)}
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		sort.Strings(keys)
+		reason := "is not a known config field"
+		if len(keys) > 1 {
+			reason = fmt.Sprintf("is not a known config field (unknown keys: %s)", strings.Join(keys, ", "))
+		}
+		return toml.MetaData{}, configError(keys[0], reason)
+	}
+	return md, nil
+}
+
+// readConfigDocument parses a config file into a generic document.
+func readConfigDocument(path string, format ConfigFormat) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ConfigError{Err: fmt.Errorf("decode config: %w", err)}
+	}
+	doc := map[string]any{}
+	switch format {
+	case FormatTOML:
+		err = toml.Unmarshal(data, &doc)
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &doc)
+	case FormatJSON:
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, &ConfigError{Err: fmt.Errorf("decode config %s: %w", path, err)}
+	}
+	return doc, nil
+}
+
+// ConvertConfigFile rewrites the config at src in the format implied by dst.
+// The source must decode strictly; comments are not carried over. An existing
+// dst is only replaced when overwrite is set.
+func ConvertConfigFile(src, dst string, overwrite bool) error {
+	if _, err := decodeConfigFile(src, &Config{}); err != nil {
+		return err
+	}
+	srcFormat, err := ConfigFormatFromPath(src)
+	if err != nil {
+		return err
+	}
+	dstFormat, err := ConfigFormatFromPath(dst)
+	if err != nil {
+		return err
+	}
+	doc, err := readConfigDocument(src, srcFormat)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	switch dstFormat {
+	case FormatTOML:
+		var buf bytes.Buffer
+		err = toml.NewEncoder(&buf).Encode(doc)
+		out = buf.Bytes()
+	case FormatYAML:
+		out, err = yaml.Marshal(doc)
+	case FormatJSON:
+		out, err = json.MarshalIndent(doc, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", dstFormat, err)
+	}
+	return writeConfigBytes(dst, out, overwrite)
+}
+
+// writeConfigBytes writes a config file with owner-only permissions.
+// An existing file is only replaced when overwrite is set.
+func writeConfigBytes(path string, data []byte, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return f.Close()
+}
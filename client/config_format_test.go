@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestDecodeConfigUnknownKey(t *testing.T) {
+	cases := []struct {
+		file, data, field string
+	}{
+		{"config.toml", "[controller]\nchain_id = \"osmo-test-5\"\nchain = \"x\"\n", "controller.chain"},
+		{"config.yaml", "controller:\n  chain_id: osmo-test-5\n  chain: x\n", "controller.chain"},
+		{"config.yml", "bogus:\n  key: x\n", "bogus"},
+		{"config.json", `{"controller": {"chain_id": "osmo-test-5", "chain": "x"}}`, "controller.chain"},
+		{"config.json", `{"profiles": {"inj": {"controller": {"chain": "x"}}}}`, "profiles.inj.controller.chain"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.file+" "+tc.field, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			if err := os.WriteFile(path, []byte(tc.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := decodeConfigFile(path, &Config{})
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tc.field {
+				t.Fatalf("err = %v, want a ConfigError for %s", err, tc.field)
+			}
+		})
+	}
+}
+
+// fullConfig returns a Config with every string field, including those of one
+// profile, set to a distinct value.
+func fullConfig() Config {
+	var cfg Config
+	for key, field := range cfg.fields() {
+		field.value.SetString("value of " + key)
+	}
+	var prof ProfileConfig
+	for key, field := range sectionFields(reflect.ValueOf(&prof).Elem()) {
+		field.value.SetString("profile value of " + key)
+	}
+	cfg.Profiles = map[string]ProfileConfig{"injective": prof}
+	return cfg
+}
+
+func TestConvertConfigFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := fullConfig()
+	src := filepath.Join(dir, "config.toml")
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := toml.NewEncoder(f).Encode(want); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dst := range []string{"config.yaml", "config.json", "roundtrip.toml"} {
+		dst = filepath.Join(dir, dst)
+		if err := ConvertConfigFile(src, dst, false); err != nil {
+			t.Fatalf("convert %s to %s: %v", filepath.Base(src), filepath.Base(dst), err)
+		}
+		var got Config
+		if _, err := decodeConfigFile(dst, &got); err != nil {
+			t.Fatalf("decode %s: %v", filepath.Base(dst), err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s does not match the source config:\n got %+v\nwant %+v", filepath.Base(dst), got, want)
+		}
+		src = dst
+	}
+
+	if err := ConvertConfigFile(src, filepath.Join(dir, "config.json"), false); err == nil {
+		t.Fatal("expected an error when the destination exists and overwrite is not set")
+	}
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+)
+
+// configTemplate renders a commented TOML config. Optional fields left empty are
+// emitted commented out so the file documents every available setting. It is
+// the only copy of the config comments: the repository's config.toml is this
+// template rendered with example values (see TestExampleConfig).
+var configTemplate = template.Must(template.New("config").Funcs(template.FuncMap{
+	"q": strconv.Quote,
+	"opt": func(key, value, example string) string {
+		if value == "" {
+			return "#" + key + " = " + strconv.Quote(example)
+		}
+		return key + " = " + strconv.Quote(value)
+	},
+}).Parse(`[lumera]
+# Chain ID of the Lumera network
+chain_id = {{q .Lumera.ChainID}}
+
+# Address of the gRPC server for the Lumera node
+grpc_endpoint = {{q .Lumera.GRPCEndpoint}}
+
+# Address of the RPC HTTP server for the Lumera node
+rpc_endpoint = {{q .Lumera.RPCEndpoint}}
+
+# SDK log level: debug, info, warn, error
+log_level = {{q .Lumera.LogLevel}}
+
+# Key name in the controller keyring to use for Lumera signing.
+key_name = {{q .Lumera.KeyName}}
+
+# Key type for the Lumera (host) chain key: "cosmos" (secp256k1) or "evm" (eth_secp256k1).
+# Default: "cosmos"
+{{opt "key_type" .Lumera.KeyType "cosmos"}}
+
+[controller]
+# Chain ID of the controller network
+chain_id = {{q .Controller.ChainID}}
+# Bech32 HRP for controller chain addresses
+account_hrp = {{q .Controller.AccountHRP}}
+# Address of the gRPC server for the controller node
+grpc_endpoint = {{q .Controller.GRPCEndpoint}}
+# Address of the CometBFT RPC server (used for tx inclusion polling)
+rpc_endpoint = {{q .Controller.RPCEndpoint}}
+# Controller chain binary; its base name is also the keyring app name.
+{{opt "binary" .Controller.Binary "gaiad"}}
+# Controller chain home directory
+{{opt "home" .Controller.Home "~/.gaia"}}
+# Gas price for controller txs, e.g. "0.03uosmo"
+{{opt "gas_prices" .Controller.GasPrices "0.025uatom"}}
+
+# Key in the controller keyring used to sign ICA txs.
+key_name = {{q .Controller.KeyName}}
+# Key type for the controller chain key: "cosmos" (secp256k1) or "evm" (eth_secp256k1).
+# Default: "cosmos"
+{{opt "key_type" .Controller.KeyType "cosmos"}}
+# This keyring is used for ICA signing and cascade metadata (no separate Lumera keyring).
+# KeyRing backend for storing keys: "file", "test", or "os"
+keyring_backend = {{q .Controller.KeyringBackend}}
+# keyring_dir is used for "file" backend; for "test" it defaults to controller home when unset.
+{{opt "keyring_dir" .Controller.KeyringDir (or .Controller.Home "~/.gaia")}}
+
+# Keyring passphrase in plain text
+{{opt "keyring_passphrase_plain" .Controller.KeyringPassphrasePlain ""}}
+
+# Keyring passphrase in a text file
+{{opt "keyring_passphrase_file" .Controller.KeyringPassphraseFile ""}}
+
+# Keyring passphrase from an env var (its name) or from a command's stdout
+# (run without a shell). Set at most one passphrase source; --passphrase-stdin
+# counts as one too.
+{{opt "keyring_passphrase_env" .Controller.KeyringPassphraseEnv "ICA_KEYRING_PASSPHRASE"}}
+{{opt "keyring_passphrase_command" .Controller.KeyringPassphraseCommand "vault kv get -field=passphrase secret/ica"}}
+
+# Signer: "keyring" (default; the keyring above) or "remote" (keys held by a remote
+# signing service; keyring settings are then ignored).
+{{opt "signer" .Controller.Signer "remote"}}
+{{opt "signer_endpoint" .Controller.SignerEndpoint "https://signer.internal:7755"}}
+{{opt "signer_tls_ca_file" .Controller.SignerTLSCAFile "~/.lumera-ica/signer-ca.pem"}}
+{{opt "signer_tls_cert_file" .Controller.SignerTLSCertFile "~/.lumera-ica/client.pem"}}
+{{opt "signer_tls_key_file" .Controller.SignerTLSKeyFile "~/.lumera-ica/client.key"}}
+
+# Controller-side IBC connection id to Lumera
+connection_id = {{q .Controller.ConnectionID}}
+# Lumera-side IBC connection id; used when building ICA version metadata.
+{{opt "counterparty_connection_id" .Controller.CounterpartyConnectionID ""}}
+
+# ICA packet timeout (Go duration). Default: "10m".
+{{opt "packet_timeout" .Controller.PacketTimeout "10m"}}
+# How long to wait for the host ack before reporting the packet as pending.
+# Default: packet_timeout.
+{{opt "ack_wait_timeout" .Controller.AckWaitTimeout "10m"}}
+
+# Optional application key, distinct from the controller key. Its pubkey is sent as
+# the action app_pubkey and it signs Cascade metadata; MsgSendTx stays signed by the
+# controller key. Without keyring_backend the key is read from the controller keyring.
+#[app_key]
+#key_name = "app"
+#key_type = "cosmos"
+#keyring_backend = "file"
+#keyring_dir = "~/.lumera-app"
+#keyring_passphrase_file = ""
+
+# Optional automatic ICA top-up policy (used by upload and action approve).
+# When the ICA balance on Lumera drops below min_balance, an ICS-20 transfer of
+# source_denom from the controller key brings it back to target_balance.
+#[funding]
+#min_balance = "1000000ulume"
+#target_balance = "10000000ulume"
+# Controller-side denom sent 1:1 (e.g. the ulume voucher on the controller chain).
+#source_denom = "ibc/<hash>"
+# Controller-side transfer channel; defaults to the open transfer channel on connection_id.
+#transfer_channel = "channel-0"
+
+# Optional named profiles for additional controller chains/ICAs, selected with
+# --profile <name>. Fields set here replace the top-level ones; the rest are inherited,
+# except that a profile with its own connection_id does not inherit
+# counterparty_connection_id, funding.transfer_channel or funding.source_denom.
+#[profiles.injective.controller]
+#chain_id = "injective-888"
+#account_hrp = "inj"
+#grpc_endpoint = "grpc.testnet.injective.network:443"
+#rpc_endpoint = "https://rpc.testnet.injective.network:443"
+#key_name = "inj-key"
+#key_type = "evm"
+#connection_id = "connection-<id>"
+#counterparty_connection_id = "connection-<lumera-side-id>"
+`))
+
+// RenderConfig renders cfg as a commented TOML config file.
+// The [app_key], [funding] and [profiles] sections are emitted as commented examples.
+func RenderConfig(cfg *Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	var buf bytes.Buffer
+	if err := configTemplate.Execute(&buf, cfg); err != nil {
+		return nil, fmt.Errorf("render config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteNewConfig validates cfg, renders it to a TOML file at path and loads the
+// file back as a final check; a file that fails to load is removed again.
+// Existing files are only replaced when overwrite is set.
+func WriteNewConfig(path string, cfg *Config, overwrite bool) error {
+	format, err := ConfigFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	if format != FormatTOML {
+		return fmt.Errorf("config init writes TOML; use `config convert` to produce %s", format)
+	}
+	// Validate a copy so "~" paths are written unexpanded.
+	check := *cfg
+	if err := check.ExpandPaths(); err != nil {
+		return err
+	}
+	if err := check.Validate(); err != nil {
+		return err
+	}
+	data, err := RenderConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeConfigBytes(path, data, overwrite); err != nil {
+		return err
+	}
+	if _, err := LoadConfig(path); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateExample = flag.Bool("update", false, "rewrite ../config.toml from the config template")
+
+// exampleConfigPath is the example config shipped at the repository root.
+const exampleConfigPath = "../config.toml"
+
+// exampleConfig holds the values rendered into the repository's config.toml.
+var exampleConfig = Config{
+	Lumera: LumeraConfig{
+		ChainID:      "lumera-testnet-2",
+		GRPCEndpoint: "grpc.testnet.lumera.io:443",
+		RPCEndpoint:  "https://rpc.testnet.lumera.io",
+		LogLevel:     "info",
+		KeyName:      "lumera",
+	},
+	Controller: ControllerConfig{
+		ChainID:                  "osmo-test-5",
+		AccountHRP:               "osmo",
+		GRPCEndpoint:             "grpc.testnet.osmosis.zone:443",
+		RPCEndpoint:              "https://rpc.testnet.osmosis.zone:443",
+		Binary:                   "osmosisd",
+		Home:                     "~/.osmosisd-testnet",
+		GasPrices:                "0.03uosmo",
+		KeyName:                  "osmosis-ibc-test",
+		KeyringBackend:           "test",
+		ConnectionID:             "connection-4370",
+		CounterpartyConnectionID: "connection-4",
+	},
+}
+
+// TestExampleConfig checks that config.toml is the config template rendered
+// with exampleConfig. Run with -update after changing the template.
+func TestExampleConfig(t *testing.T) {
+	data, err := RenderConfig(&exampleConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *updateExample {
+		if err := os.WriteFile(exampleConfigPath, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	have, err := os.ReadFile(exampleConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, data) {
+		t.Fatalf("%s is out of date with the config template; run go test ./client -run TestExampleConfig -update", exampleConfigPath)
+	}
+}
+
+func TestWriteNewConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := WriteNewConfig(path, &exampleConfig, false); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Controller.ConnectionID != exampleConfig.Controller.ConnectionID || cfg.Lumera.KeyName != exampleConfig.Lumera.KeyName {
+		t.Fatalf("loaded config does not match: %+v", cfg)
+	}
+	if err := WriteNewConfig(path, &exampleConfig, false); err == nil {
+		t.Fatal("expected an error when the file exists and overwrite is not set")
+	}
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnvPrefix prefixes environment overrides: LUMERA_ICA_<SECTION>_<FIELD>.
+const EnvPrefix = "LUMERA_ICA_"
+
+// ConfigSource names the layer that supplied a config value.
+type ConfigSource string
+
+const (
+	SourceUnset   ConfigSource = "unset"
+	SourceDefault ConfigSource = "default"
+	SourceFile    ConfigSource = "file"
+	SourceProfile ConfigSource = "profile"
+	SourceEnv     ConfigSource = "env"
+	SourceFlag    ConfigSource = "flag"
+)
+
+// ConfigSources maps "section.field" keys to the layer that set them.
+type ConfigSources map[string]ConfigSource
+
+// configDefaults are the built-in values applied before the config file.
+var configDefaults = map[string]string{
+	"lumera.log_level":    "info",
+	"lumera.key_type":     "cosmos",
+	"controller.key_type": "cosmos",
+	"controller.signer":   "keyring",
+}
+
+// connectionBoundFields only make sense for the top-level connection_id: the
+// Lumera-side connection, the ICS-20 channel on it and the IBC voucher denom
+// that channel produces. A profile with its own connection_id does not
+// inherit them.
+var connectionBoundFields = []string{
+	"controller.counterparty_connection_id",
+	"funding.transfer_channel",
+	"funding.source_denom",
+}
+
+// LoadOptions selects the override layers applied on top of the config file.
+type LoadOptions struct {
+	// Profile names a [profiles.<name>] block applied over the top-level sections; empty uses them as-is.
+	Profile string
+	// Environ holds "KEY=value" entries (typically os.Environ()); nil skips env overrides.
+	Environ []string
+	// Overrides holds "section.field=value" entries, applied last.
+	Overrides []string
+	// Passphrase is the controller keyring passphrase read from stdin; nil when
+	// not given. It counts as a controller passphrase source and is not copied.
+	Passphrase []byte
+}
+
+// ConfigValue is one resolved config field.
+type ConfigValue struct {
+	Key    string
+	Value  string
+	Source ConfigSource
+	Secret bool
+}
+
+// LoadConfigWithOptions resolves the config from built-in defaults, the config
+// file (TOML, YAML or JSON by extension), the selected profile,
+// LUMERA_ICA_<SECTION>_<FIELD> environment variables and "section.field=value"
+// overrides, in that order. It expands paths, validates the result and reports
+// which layer set each field.
+func LoadConfigWithOptions(path string, opts LoadOptions) (*Config, ConfigSources, error) {
+	var cfg Config
+	fields := cfg.fields()
+	sources := ConfigSources{}
+	for key, value := range configDefaults {
+		fields[key].value.SetString(value)
+		sources[key] = SourceDefault
+	}
+	md, err := decodeConfigFile(path, &cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key := range fields {
+		section, name, _ := strings.Cut(key, ".")
+		if md.IsDefined(section, name) {
+			sources[key] = SourceFile
+		}
+	}
+	if profile := strings.TrimSpace(opts.Profile); profile != "" {
+		prof, ok := cfg.Profiles[profile]
+		if !ok {
+			return nil, nil, &ConfigError{Field: "profiles." + profile, Reason: fmt.Sprintf("is not defined in %s (profiles: %s)", path, strings.Join(cfg.ProfileNames(), ", "))}
+		}
+		for key, field := range sectionFields(reflect.ValueOf(&prof).Elem()) {
+			section, name, _ := strings.Cut(key, ".")
+			if md.IsDefined("profiles", profile, section, name) {
+				fields[key].value.SetString(field.value.String())
+				sources[key] = SourceProfile
+			}
+		}
+		if md.IsDefined("profiles", profile, "controller", "connection_id") {
+			for _, key := range connectionBoundFields {
+				section, name, _ := strings.Cut(key, ".")
+				if !md.IsDefined("profiles", profile, section, name) {
+					fields[key].value.SetString("")
+					delete(sources, key)
+				}
+			}
+		}
+	}
+	if opts.Environ != nil {
+		env := make(map[string]string, len(opts.Environ))
+		for _, entry := range opts.Environ {
+			if k, v, ok := strings.Cut(entry, "="); ok && strings.HasPrefix(k, EnvPrefix) {
+				env[k] = v
+			}
+		}
+		for key, field := range fields {
+			if value, ok := env[EnvName(key)]; ok {
+				field.value.SetString(value)
+				sources[key] = SourceEnv
+			}
+		}
+	}
+	for _, override := range opts.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, nil, &ConfigError{Reason: fmt.Sprintf("override %q must have the form section.field=value", override)}
+		}
+		field, ok := fields[key]
+		if !ok {
+			return nil, nil, configError(key, "is not a known config field")
+		}
+		field.value.SetString(value)
+		sources[key] = SourceFlag
+	}
+	cfg.Controller.stdinPassphrase = opts.Passphrase
+	if err := cfg.ExpandPaths(); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, sources, nil
+}
+
+// EnvName returns the environment variable overriding a "section.field" key.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Values lists every config field in key order with its source; fields absent
+// from sources are reported as unset.
+func (c *Config) Values(sources ConfigSources) []ConfigValue {
+	fields := c.fields()
+	values := make([]ConfigValue, 0, len(fields))
+	for key, field := range fields {
+		source, ok := sources[key]
+		if !ok {
+			source = SourceUnset
+		}
+		values = append(values, ConfigValue{Key: key, Value: field.value.String(), Source: source, Secret: field.secret})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Key < values[j].Key })
+	return values
+}
+
+// configField is a settable string field of a config section.
+type configField struct {
+	value  reflect.Value
+	secret bool
+}
+
+// ProfileNames returns the names of the [profiles.<name>] blocks, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadProfileNames decodes the config file without applying layers or
+// validation and returns its profile names.
+func ReadProfileNames(path string) ([]string, error) {
+	var cfg Config
+	if _, err := decodeConfigFile(path, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.ProfileNames(), nil
+}
+
+// fields indexes the string fields of each TOML section by "section.field".
+func (c *Config) fields() map[string]configField {
+	return sectionFields(reflect.ValueOf(c).Elem())
+}
+
+// sectionFields indexes the string fields of each struct section of root.
+// Non-struct fields such as Config.Profiles are skipped.
+func sectionFields(root reflect.Value) map[string]configField {
+	out := map[string]configField{}
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Field(i)
+		sectionName := tomlName(root.Type().Field(i))
+		if section.Kind() != reflect.Struct || sectionName == "" {
+			continue
+		}
+		for j := 0; j < section.NumField(); j++ {
+			sf := section.Type().Field(j)
+			name := tomlName(sf)
+			if name == "" || sf.Type.Kind() != reflect.String {
+				continue
+			}
+			out[sectionName+"."+name] = configField{value: section.Field(j), secret: sf.Tag.Get("secret") == "true"}
+		}
+	}
+	return out
+}
+
+// tomlName returns the TOML key of a struct field, or "" when it has none.
+func tomlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// layersTestConfig leaves lumera.log_level to its built-in default.
+const layersTestConfig = `
+[lumera]
+chain_id = "lumera-testnet-2"
+grpc_endpoint = "localhost:9090"
+rpc_endpoint = "http://localhost:26657"
+key_name = "lumera"
+
+[controller]
+chain_id = "osmo-test-5"
+account_hrp = "osmo"
+grpc_endpoint = "localhost:9091"
+rpc_endpoint = "http://localhost:26658"
+key_name = "osmo-key"
+keyring_backend = "test"
+connection_id = "connection-1"
+`
+
+// writeLayersConfig writes layersTestConfig to a temp file and returns its path.
+func writeLayersConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(layersTestConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	path := writeLayersConfig(t)
+	cases := []struct {
+		name      string
+		environ   []string
+		overrides []string
+		want      map[string]wantValue
+	}{
+		{
+			name: "defaults and file",
+			want: map[string]wantValue{
+				"lumera.log_level":    {"info", SourceDefault},
+				"controller.key_name": {"osmo-key", SourceFile},
+				"controller.binary":   {"", SourceUnset},
+			},
+		},
+		{
+			name: "env over defaults and file",
+			environ: []string{
+				"HOME=/root",
+				EnvName("lumera.log_level") + "=debug",
+				EnvName("controller.key_name") + "=env-key",
+				EnvPrefix + "CONTROLLER_NOPE=ignored",
+			},
+			want: map[string]wantValue{
+				"lumera.log_level":    {"debug", SourceEnv},
+				"controller.key_name": {"env-key", SourceEnv},
+				"controller.chain_id": {"osmo-test-5", SourceFile},
+			},
+		},
+		{
+			name:      "set over env",
+			environ:   []string{EnvName("lumera.log_level") + "=debug", EnvName("controller.key_name") + "=env-key"},
+			overrides: []string{"controller.key_name=flag-key", " controller.binary =osmosisd"},
+			want: map[string]wantValue{
+				"lumera.log_level":    {"debug", SourceEnv},
+				"controller.key_name": {"flag-key", SourceFlag},
+				"controller.binary":   {"osmosisd", SourceFlag},
+			},
+		},
+		{
+			name:      "last set wins",
+			overrides: []string{"lumera.log_level=warn", "lumera.log_level=error"},
+			want: map[string]wantValue{
+				"lumera.log_level": {"error", SourceFlag},
+			},
+		},
+		{
+			name:      "set value containing =",
+			overrides: []string{"controller.binary=a=b"},
+			want: map[string]wantValue{
+				"controller.binary": {"a=b", SourceFlag},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, sources, err := LoadConfigWithOptions(path, LoadOptions{Environ: tc.environ, Overrides: tc.overrides})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkValues(t, cfg, sources, tc.want)
+		})
+	}
+}
+
+func TestLoadConfigOverrideErrors(t *testing.T) {
+	path := writeLayersConfig(t)
+	cases := []struct {
+		name     string
+		override string
+		field    string
+		reason   string
+	}{
+		{"unknown key", "controller.nope=1", "controller.nope", "is not a known config field"},
+		{"unknown section", "nope.key_name=1", "nope.key_name", "is not a known config field"},
+		{"missing =", "controller.key_name", "", "must have the form section.field=value"},
+		{"missing key", "=value", "", "must have the form section.field=value"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := LoadConfigWithOptions(path, LoadOptions{Overrides: []string{tc.override}})
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || !errors.Is(err, ErrConfigInvalid) {
+				t.Fatalf("err = %v, want a ConfigError", err)
+			}
+			if cfgErr.Field != tc.field || !strings.Contains(cfgErr.Reason, tc.reason) {
+				t.Fatalf("err = %+v, want field %q and reason %q", cfgErr, tc.field, tc.reason)
+			}
+		})
+	}
+}
+
+func TestConfigValuesSecret(t *testing.T) {
+	path := writeLayersConfig(t)
+	cfg, sources, err := LoadConfigWithOptions(path, LoadOptions{
+		Environ: []string{EnvName("controller.keyring_passphrase_plain") + "=s3cretpass"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range cfg.Values(sources) {
+		wantSecret := strings.HasSuffix(v.Key, ".keyring_passphrase_plain")
+		if v.Secret != wantSecret {
+			t.Errorf("%s: secret = %v, want %v", v.Key, v.Secret, wantSecret)
+		}
+		if v.Key == "controller.keyring_passphrase_plain" && (v.Value != "s3cretpass" || v.Source != SourceEnv) {
+			t.Errorf("%s = %q from %s, want the env value", v.Key, v.Value, v.Source)
+		}
+	}
+}
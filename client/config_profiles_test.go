@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestConfig writes exampleConfig followed by extra TOML to a temp file
+// and returns its path.
+func writeTestConfig(t *testing.T, extra string) string {
+	t.Helper()
+	data, err := RenderConfig(&exampleConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, append(data, extra...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testProfiles = `
+[funding]
+min_balance = "1000000ulume"
+target_balance = "10000000ulume"
+source_denom = "ibc/OSMOULUME"
+transfer_channel = "channel-7"
+
+[profiles.osmo2.controller]
+key_name = "osmo-second"
+
+[profiles.injective.controller]
+chain_id = "injective-888"
+account_hrp = "inj"
+key_name = "inj-key"
+key_type = "evm"
+connection_id = "connection-200"
+
+[profiles.injective.funding]
+source_denom = "ibc/INJULUME"
+
+[profiles.nodenom.controller]
+chain_id = "injective-888"
+connection_id = "connection-200"
+`
+
+func TestLoadConfigProfiles(t *testing.T) {
+	path := writeTestConfig(t, testProfiles)
+
+	cfg, sources, err := LoadConfigWithOptions(path, LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Controller.ChainID != "osmo-test-5" || sources["controller.chain_id"] != SourceFile {
+		t.Fatalf("without a profile: chain_id = %q from %s", cfg.Controller.ChainID, sources["controller.chain_id"])
+	}
+
+	// A profile on the same connection inherits everything it leaves out.
+	cfg, sources, err = LoadConfigWithOptions(path, LoadOptions{Profile: "osmo2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]wantValue{
+		"controller.key_name":                   {"osmo-second", SourceProfile},
+		"controller.chain_id":                   {"osmo-test-5", SourceFile},
+		"controller.counterparty_connection_id": {"connection-4", SourceFile},
+		"funding.transfer_channel":              {"channel-7", SourceFile},
+		"funding.source_denom":                  {"ibc/OSMOULUME", SourceFile},
+	}
+	checkValues(t, cfg, sources, want)
+
+	// A profile on another connection drops the fields tied to the top-level one.
+	cfg, sources, err = LoadConfigWithOptions(path, LoadOptions{Profile: "injective"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, cfg, sources, map[string]wantValue{
+		"controller.chain_id":                   {"injective-888", SourceProfile},
+		"controller.connection_id":              {"connection-200", SourceProfile},
+		"controller.key_type":                   {"evm", SourceProfile},
+		"controller.grpc_endpoint":              {"grpc.testnet.osmosis.zone:443", SourceFile},
+		"controller.counterparty_connection_id": {"", SourceUnset},
+		"funding.transfer_channel":              {"", SourceUnset},
+		"funding.source_denom":                  {"ibc/INJULUME", SourceProfile},
+		"funding.min_balance":                   {"1000000ulume", SourceFile},
+	})
+
+	// With funding enabled, such a profile must name its own source denom.
+	_, _, err = LoadConfigWithOptions(path, LoadOptions{Profile: "nodenom"})
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "funding.source_denom" {
+		t.Fatalf("err = %v, want a ConfigError for funding.source_denom", err)
+	}
+
+	// Env overrides still apply on top of a profile.
+	cfg, sources, err = LoadConfigWithOptions(path, LoadOptions{
+		Profile: "injective",
+		Environ: []string{EnvName("controller.counterparty_connection_id") + "=connection-12"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, cfg, sources, map[string]wantValue{
+		"controller.counterparty_connection_id": {"connection-12", SourceEnv},
+	})
+}
+
+func TestLoadConfigUndefinedProfile(t *testing.T) {
+	path := writeTestConfig(t, testProfiles)
+	_, _, err := LoadConfigWithOptions(path, LoadOptions{Profile: "nope"})
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "profiles.nope" {
+		t.Fatalf("err = %v, want a ConfigError for profiles.nope", err)
+	}
+	if !errors.Is(err, ErrConfigInvalid) {
+		t.Fatalf("err = %v, want ErrConfigInvalid", err)
+	}
+}
+
+func TestLoadConfigInvalidProfileName(t *testing.T) {
+	path := writeTestConfig(t, "\n[profiles.\"bad name\".controller]\nkey_name = \"k\"\n")
+	_, _, err := LoadConfigWithOptions(path, LoadOptions{})
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "profiles.bad name" {
+		t.Fatalf("err = %v, want a ConfigError for profiles.bad name", err)
+	}
+}
+
+func TestReadProfileNames(t *testing.T) {
+	names, err := ReadProfileNames(writeTestConfig(t, testProfiles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"injective", "nodenom", "osmo2"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+}
+
+func TestProfileJournalPath(t *testing.T) {
+	cases := []struct {
+		config, profile, want string
+	}{
+		{"/etc/lic/config.toml", "", "/etc/lic/journal.db"},
+		{"/etc/lic/config.toml", "injective", "/etc/lic/journal.injective.db"},
+		{"config.yaml", "osmo2", "journal.osmo2.db"},
+	}
+	for _, tc := range cases {
+		if got := ProfileJournalPath(tc.config, tc.profile); got != tc.want {
+			t.Errorf("ProfileJournalPath(%q, %q) = %q, want %q", tc.config, tc.profile, got, tc.want)
+		}
+	}
+}
+
+// wantValue is the expected value and source of a resolved config field.
+type wantValue struct {
+	value  string
+	source ConfigSource
+}
+
+// checkValues compares resolved fields and their sources.
+func checkValues(t *testing.T, cfg *Config, sources ConfigSources, want map[string]wantValue) {
+	t.Helper()
+	values := map[string]ConfigValue{}
+	for _, v := range cfg.Values(sources) {
+		values[v.Key] = v
+	}
+	for key, w := range want {
+		got := values[key]
+		if got.Value != w.value || got.Source != w.source {
+			t.Errorf("%s = %q from %s, want %q from %s", key, got.Value, got.Source, w.value, w.source)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultSupernodePort  = 4444
+	maxProbedSupernodes   = 10
+	activeSupernodeState  = "SUPERNODE_STATE_ACTIVE"
+	defaultSupernodeProbe = 5 * time.Second
+)
+
+// SupernodeProbe is the result of dialing one supernode's gRPC endpoint.
+type SupernodeProbe struct {
+	ValidatorAddress string
+	Endpoint         string
+	Err              error
+}
+
+// NodeChainID returns the chain ID (network) reported by the node behind conn.
+func NodeChainID(ctx context.Context, conn *grpc.ClientConn) (string, error) {
+	resp, err := cmtservice.NewServiceClient(conn).GetNodeInfo(ctx, &cmtservice.GetNodeInfoRequest{})
+	if err != nil {
+		return "", fmt.Errorf("query node info: %w", err)
+	}
+	if resp.GetDefaultNodeInfo() == nil {
+		return "", fmt.Errorf("node info is empty")
+	}
+	return resp.GetDefaultNodeInfo().Network, nil
+}
+
+// ControllerChainID returns the chain ID reported by the controller chain node.
+func (c *Controller) ControllerChainID(ctx context.Context) (string, error) {
+	if c == nil || c.controllerBC == nil {
+		return "", ErrControllerNotInitialized
+	}
+	return NodeChainID(ctx, c.controllerBC.GRPCConn())
+}
+
+// Connection returns controller.connection_id as seen by the controller chain.
+func (c *Controller) Connection(ctx context.Context) (*connectiontypes.ConnectionEnd, error) {
+	if c == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	connectionID := c.cfg.Controller.ConnectionID
+	resp, err := connectiontypes.NewQueryClient(c.controllerBC.GRPCConn()).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return nil, fmt.Errorf("query ibc connection %s: %w", connectionID, err)
+	}
+	if resp.GetConnection() == nil {
+		return nil, fmt.Errorf("ibc connection %s is empty", connectionID)
+	}
+	return resp.GetConnection(), nil
+}
+
+// ProbeSupernodes dials the ACTIVE top supernodes for the latest Lumera block
+// over TCP. Endpoints without a port use the default supernode port. The dials
+// run concurrently so a dead node cannot use up the time of the others.
+func ProbeSupernodes(ctx context.Context, bc *blockchain.Client) ([]SupernodeProbe, error) {
+	block, err := cmtservice.NewServiceClient(bc.GRPCConn()).GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("query latest block: %w", err)
+	}
+	height := block.GetSdkBlock().GetHeader().Height
+	supernodes, err := bc.SuperNode.GetTopSuperNodesForBlockWithOptions(ctx, int32(height), maxProbedSupernodes, activeSupernodeState)
+	if err != nil {
+		return nil, err
+	}
+	probes := make([]SupernodeProbe, 0, len(supernodes))
+	for _, sn := range supernodes {
+		if sn == nil {
+			continue
+		}
+		endpoint := sn.IPAddress
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			endpoint = net.JoinHostPort(endpoint, strconv.Itoa(defaultSupernodePort))
+		}
+		probes = append(probes, SupernodeProbe{ValidatorAddress: sn.ValidatorAddress, Endpoint: endpoint})
+	}
+	var wg sync.WaitGroup
+	for i := range probes {
+		wg.Go(func() {
+			probes[i].Err = dialSupernode(ctx, probes[i].Endpoint)
+		})
+	}
+	wg.Wait()
+	return probes, nil
+}
+
+// dialSupernode opens and closes one TCP connection to endpoint.
+func dialSupernode(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultSupernodeProbe)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
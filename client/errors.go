@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the failure classes of the client package. Typed errors
+// match their sentinel with errors.Is and carry details for errors.As:
+//
+//   - *ConfigError matches ErrConfigInvalid.
+//   - *KeyTypeMismatchError matches ErrKeyTypeMismatch.
+//   - *AckError matches ErrAckError.
+//   - *RemoteSignerError matches ErrRemoteSigner.
+//   - *KeyringUnlockError matches ErrWrongPassphrase or ErrPassphraseRequired.
+//
+// InsufficientBalanceError, ChannelClosedError, AckPendingError,
+// PacketTimeoutError and TxFailedError have no sentinel.
+var (
+	ErrConfigInvalid            = errors.New("invalid config")
+	ErrKeyNotFound              = errors.New("key not found in keyring")
+	ErrKeyExists                = errors.New("key already exists in keyring")
+	ErrKeyTypeMismatch          = errors.New("key type mismatch")
+	ErrControllerNotInitialized = errors.New("ica controller is not initialized")
+	ErrICANotRegistered         = errors.New("ica is not registered")
+	ErrAckError                 = errors.New("ack error")
+	ErrRemoteSigner             = errors.New("remote signer error")
+	ErrWrongPassphrase          = errors.New("wrong keyring passphrase")
+	ErrPassphraseRequired       = errors.New("keyring passphrase required")
+)
+
+// ConfigError reports an invalid config field. Field is the dotted TOML key
+// (e.g. "controller.connection_id") and is empty for whole-file errors.
+type ConfigError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+func (e *ConfigError) Error() string {
+	switch {
+	case e.Field == "" && e.Err != nil:
+		return e.Err.Error()
+	case e.Field == "":
+		return e.Reason
+	case e.Err != nil:
+		return fmt.Sprintf("%s: %v", e.Field, e.Err)
+	default:
+		return fmt.Sprintf("%s %s", e.Field, e.Reason)
+	}
+}
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+func (e *ConfigError) Is(target error) bool { return target == ErrConfigInvalid }
+
+// configError builds a *ConfigError such as "lumera.chain_id is required".
+func configError(field, reason string) error {
+	return &ConfigError{Field: field, Reason: reason}
+}
+
+// wrapConfigError builds a *ConfigError for a field whose value failed to parse.
+func wrapConfigError(field string, err error) error {
+	return &ConfigError{Field: field, Err: err}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	controllertypes "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/controller/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateKeyTypeErrors(t *testing.T) {
+	kr := keyring.NewInMemory(keyringCodec(), keyringAlgos)
+	if _, err := CreateKey(kr, "alice", "cosmos"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateKeyType(kr, "alice", "cosmos"); err != nil {
+		t.Fatalf("matching key type: %v", err)
+	}
+
+	err := validateKeyType(kr, "carol", "cosmos")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("missing key: err = %v, want ErrKeyNotFound", err)
+	}
+
+	err = validateKeyType(kr, "alice", "evm")
+	var mismatch *KeyTypeMismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, ErrKeyTypeMismatch) {
+		t.Fatalf("wrong algo: err = %v, want a KeyTypeMismatchError", err)
+	}
+	if mismatch.KeyName != "alice" || mismatch.Expected != "eth_secp256k1" || mismatch.Actual != "secp256k1" {
+		t.Fatalf("mismatch = %+v", mismatch)
+	}
+}
+
+func TestValidateMissingField(t *testing.T) {
+	cases := []struct {
+		field string
+		clear func(*Config)
+	}{
+		{"lumera.chain_id", func(c *Config) { c.Lumera.ChainID = "" }},
+		{"lumera.key_name", func(c *Config) { c.Lumera.KeyName = " " }},
+		{"controller.chain_id", func(c *Config) { c.Controller.ChainID = "" }},
+		{"controller.connection_id", func(c *Config) { c.Controller.ConnectionID = "" }},
+		{"controller.keyring_backend", func(c *Config) { c.Controller.KeyringBackend = "" }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.field, func(t *testing.T) {
+			cfg := exampleConfig
+			tc.clear(&cfg)
+			err := cfg.Validate()
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || !errors.Is(err, ErrConfigInvalid) || cfgErr.Field != tc.field {
+				t.Fatalf("err = %v, want a ConfigError for %s", err, tc.field)
+			}
+		})
+	}
+}
+
+func TestDecodeAckError(t *testing.T) {
+	ok := channeltypes.NewResultAcknowledgement([]byte("result"))
+	if _, err := decodeAck(ok.Acknowledgement()); err != nil {
+		t.Fatalf("result ack: %v", err)
+	}
+
+	ack := channeltypes.NewErrorAcknowledgement(errors.New("host rejected the message"))
+	decoded, err := decodeAck(ack.Acknowledgement())
+	var ackErr *AckError
+	if !errors.As(err, &ackErr) || !errors.Is(err, ErrAckError) {
+		t.Fatalf("err = %v, want an AckError", err)
+	}
+	if decoded == nil || ackErr.Log != ack.GetError() {
+		t.Fatalf("ack = %v, log = %q, want %q", decoded, ackErr.Log, ack.GetError())
+	}
+	if got := newAckError("ABCI code: 5: error handling packet"); got.Code != 5 {
+		t.Fatalf("code = %d, want 5", got.Code)
+	}
+}
+
+// icaQueryServer answers InterchainAccount queries with a fixed result.
+type icaQueryServer struct {
+	controllertypes.UnimplementedQueryServer
+	address string
+	err     error
+}
+
+func (s *icaQueryServer) InterchainAccount(context.Context, *controllertypes.QueryInterchainAccountRequest) (*controllertypes.QueryInterchainAccountResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &controllertypes.QueryInterchainAccountResponse{Address: s.address}, nil
+}
+
+// newTestController returns a Controller whose chains are both served on a
+// loopback listener by the services register adds.
+func newTestController(t *testing.T, register func(*grpc.Server)) *Controller {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	register(gs)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	kr := keyring.NewInMemory(keyringCodec(), keyringAlgos)
+	for _, name := range []string{exampleConfig.Controller.KeyName, exampleConfig.Lumera.KeyName} {
+		if _, err := CreateKey(kr, name, "cosmos"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := exampleConfig
+	cfg.Lumera.GRPCEndpoint = lis.Addr().String()
+	cfg.Controller.GRPCEndpoint = lis.Addr().String()
+	c, err := NewICAController(context.Background(), &cfg, kr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestICAAddressNotRegistered(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name          string
+		srv           *icaQueryServer
+		notRegistered bool
+	}{
+		{"not found", &icaQueryServer{err: status.Error(codes.NotFound, "no account found for portID icacontroller-osmo1")}, true},
+		{"empty address", &icaQueryServer{}, true},
+		{"unavailable", &icaQueryServer{err: status.Error(codes.Unavailable, "node syncing")}, false},
+		{"registered", &icaQueryServer{address: "lumera1ica"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestController(t, func(gs *grpc.Server) { controllertypes.RegisterQueryServer(gs, tc.srv) })
+			addr, err := c.ICAAddress(ctx)
+			if got := errors.Is(err, ErrICANotRegistered); got != tc.notRegistered {
+				t.Fatalf("err = %v, ErrICANotRegistered = %v", err, got)
+			}
+			if tc.srv.address != "" && (err != nil || addr != tc.srv.address) {
+				t.Fatalf("addr = %q, err = %v, want %q", addr, err, tc.srv.address)
+			}
+		})
+	}
+}
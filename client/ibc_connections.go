@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/blockchain/base"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v10/modules/light-clients/07-tendermint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConnectionPair is an OPEN IBC connection between the controller chain and Lumera.
+type ConnectionPair struct {
+	// ConnectionID is the controller-side connection (controller.connection_id).
+	ConnectionID string
+	// CounterpartyConnectionID is the Lumera-side connection (controller.counterparty_connection_id).
+	CounterpartyConnectionID string
+	ClientID                 string
+}
+
+// DiscoverConnections finds OPEN connection pairs between controller.chain_id and lumera.chain_id.
+// It walks the Lumera connections, keeps those whose light client tracks the controller
+// chain, and confirms that the controller-side connection points back to Lumera.
+// Candidates whose Lumera client state cannot be read, or whose counterparty
+// connection does not exist on the controller chain, are skipped.
+func DiscoverConnections(ctx context.Context, cfg *Config) ([]ConnectionPair, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	hostBC, err := base.New(ctx, blockchain.Config{
+		ChainID:        cfg.Lumera.ChainID,
+		GRPCAddr:       cfg.Lumera.GRPCEndpoint,
+		Timeout:        defaultGRPCTimeout,
+		MaxRecvMsgSize: defaultMaxGRPCMsgSize,
+		MaxSendMsgSize: defaultMaxGRPCMsgSize,
+	}, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("create lumera chain client: %w", err)
+	}
+	defer hostBC.Close()
+	controllerBC, err := base.New(ctx, blockchain.Config{
+		ChainID:        cfg.Controller.ChainID,
+		GRPCAddr:       cfg.Controller.GRPCEndpoint,
+		Timeout:        defaultGRPCTimeout,
+		MaxRecvMsgSize: defaultMaxGRPCMsgSize,
+		MaxSendMsgSize: defaultMaxGRPCMsgSize,
+	}, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("create controller chain client: %w", err)
+	}
+	defer controllerBC.Close()
+
+	hostConns, err := listOpenConnections(ctx, hostBC.GRPCConn())
+	if err != nil {
+		return nil, fmt.Errorf("lumera: %w", err)
+	}
+	controllerQuery := connectiontypes.NewQueryClient(controllerBC.GRPCConn())
+	var pairs []ConnectionPair
+	for _, hostConn := range hostConns {
+		chainID, err := clientChainID(ctx, hostBC.GRPCConn(), hostConn.ClientId)
+		if err != nil || chainID != cfg.Controller.ChainID || hostConn.Counterparty.ConnectionId == "" {
+			continue
+		}
+		resp, err := controllerQuery.Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: hostConn.Counterparty.ConnectionId})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query controller connection %s: %w", hostConn.Counterparty.ConnectionId, err)
+		}
+		controllerConn := resp.GetConnection()
+		if controllerConn == nil || controllerConn.State != connectiontypes.OPEN ||
+			controllerConn.Counterparty.ConnectionId != hostConn.Id {
+			continue
+		}
+		chainID, err = clientChainID(ctx, controllerBC.GRPCConn(), controllerConn.ClientId)
+		if err != nil {
+			return nil, fmt.Errorf("controller: %w", err)
+		}
+		if chainID != cfg.Lumera.ChainID {
+			continue
+		}
+		pairs = append(pairs, ConnectionPair{
+			ConnectionID:             hostConn.Counterparty.ConnectionId,
+			CounterpartyConnectionID: hostConn.Id,
+			ClientID:                 controllerConn.ClientId,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		a, _ := connectiontypes.ParseConnectionSequence(pairs[i].ConnectionID)
+		b, _ := connectiontypes.ParseConnectionSequence(pairs[j].ConnectionID)
+		return a < b
+	})
+	return pairs, nil
+}
+
+// listOpenConnections pages through all connections and keeps the OPEN ones.
+func listOpenConnections(ctx context.Context, conn *grpc.ClientConn) ([]*connectiontypes.IdentifiedConnection, error) {
+	connQuery := connectiontypes.NewQueryClient(conn)
+	var open []*connectiontypes.IdentifiedConnection
+	var nextKey []byte
+	for {
+		resp, err := connQuery.Connections(ctx, &connectiontypes.QueryConnectionsRequest{Pagination: &query.PageRequest{Key: nextKey}})
+		if err != nil {
+			return nil, fmt.Errorf("query ibc connections: %w", err)
+		}
+		for _, c := range resp.GetConnections() {
+			if c.State == connectiontypes.OPEN {
+				open = append(open, c)
+			}
+		}
+		nextKey = resp.GetPagination().GetNextKey()
+		if len(nextKey) == 0 {
+			return open, nil
+		}
+	}
+}
+
+// listConnectionChannels pages through all channels on a connection. ICA
+// channels of every owner share the connection, so a single page can miss one.
+func listConnectionChannels(ctx context.Context, conn *grpc.ClientConn, connectionID string) ([]*channeltypes.IdentifiedChannel, error) {
+	chanQuery := channeltypes.NewQueryClient(conn)
+	var channels []*channeltypes.IdentifiedChannel
+	var nextKey []byte
+	for {
+		resp, err := chanQuery.ConnectionChannels(ctx, &channeltypes.QueryConnectionChannelsRequest{
+			Connection: connectionID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query channels for %s: %w", connectionID, err)
+		}
+		channels = append(channels, resp.GetChannels()...)
+		nextKey = resp.GetPagination().GetNextKey()
+		if len(nextKey) == 0 {
+			return channels, nil
+		}
+	}
+}
+
+// clientChainID returns the chain ID tracked by a Tendermint light client,
+// or "" for other client types.
+func clientChainID(ctx context.Context, conn *grpc.ClientConn, clientID string) (string, error) {
+	resp, err := clienttypes.NewQueryClient(conn).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return "", fmt.Errorf("query client state %s: %w", clientID, err)
+	}
+	state := resp.GetClientState()
+	if state == nil || strings.TrimPrefix(state.TypeUrl, "/") != gogoproto.MessageName(&ibctm.ClientState{}) {
+		return "", nil
+	}
+	var tm ibctm.ClientState
+	if err := gogoproto.Unmarshal(state.Value, &tm); err != nil {
+		return "", fmt.Errorf("decode client state %s: %w", clientID, err)
+	}
+	return tm.ChainId, nil
+}
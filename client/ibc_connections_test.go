@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
+	ibctm "github.com/cosmos/ibc-go/v10/modules/light-clients/07-tendermint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ibcChainServer serves the connection and client queries of one chain.
+// Missing entries answer NotFound; connErr, if set, answers every Connection query.
+type ibcChainServer struct {
+	connectiontypes.UnimplementedQueryServer
+	clientServer
+	connections []*connectiontypes.IdentifiedConnection
+	connErr     error
+}
+
+type clientServer struct {
+	clienttypes.UnimplementedQueryServer
+	chainIDs map[string]string
+}
+
+func (s *ibcChainServer) Connections(context.Context, *connectiontypes.QueryConnectionsRequest) (*connectiontypes.QueryConnectionsResponse, error) {
+	return &connectiontypes.QueryConnectionsResponse{Connections: s.connections}, nil
+}
+
+func (s *ibcChainServer) Connection(_ context.Context, req *connectiontypes.QueryConnectionRequest) (*connectiontypes.QueryConnectionResponse, error) {
+	if s.connErr != nil {
+		return nil, s.connErr
+	}
+	for _, c := range s.connections {
+		if c.Id == req.ConnectionId {
+			end := connectiontypes.NewConnectionEnd(c.State, c.ClientId, c.Counterparty, c.Versions, c.DelayPeriod)
+			return &connectiontypes.QueryConnectionResponse{Connection: &end}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "connection %s not found", req.ConnectionId)
+}
+
+func (s *clientServer) ClientState(_ context.Context, req *clienttypes.QueryClientStateRequest) (*clienttypes.QueryClientStateResponse, error) {
+	chainID, ok := s.chainIDs[req.ClientId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "client %s not found", req.ClientId)
+	}
+	state, err := codectypes.NewAnyWithValue(&ibctm.ClientState{ChainId: chainID})
+	if err != nil {
+		return nil, err
+	}
+	return &clienttypes.QueryClientStateResponse{ClientState: state}, nil
+}
+
+// serveIBCChain starts srv on a loopback listener and returns its address.
+func serveIBCChain(t *testing.T, srv *ibcChainServer) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	connectiontypes.RegisterQueryServer(gs, srv)
+	clienttypes.RegisterQueryServer(gs, &srv.clientServer)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+	return lis.Addr().String()
+}
+
+func openConnection(id, clientID, counterparty string) *connectiontypes.IdentifiedConnection {
+	return &connectiontypes.IdentifiedConnection{
+		Id:           id,
+		ClientId:     clientID,
+		State:        connectiontypes.OPEN,
+		Counterparty: connectiontypes.Counterparty{ConnectionId: counterparty},
+	}
+}
+
+func TestDiscoverConnections(t *testing.T) {
+	lumera := &ibcChainServer{
+		clientServer: clientServer{chainIDs: map[string]string{
+			"07-tendermint-1": "osmo-test-5",
+			"07-tendermint-2": "osmo-test-5",
+			"07-tendermint-3": "injective-888",
+		}},
+		connections: []*connectiontypes.IdentifiedConnection{
+			openConnection("connection-0", "07-tendermint-0", "connection-7"), // unreadable client state
+			openConnection("connection-1", "07-tendermint-1", "connection-9"), // unknown on the controller
+			openConnection("connection-2", "07-tendermint-2", "connection-5"),
+			openConnection("connection-3", "07-tendermint-3", "connection-5"),
+		},
+	}
+	controller := &ibcChainServer{
+		clientServer: clientServer{chainIDs: map[string]string{"07-tendermint-8": "lumera-testnet-2"}},
+		connections: []*connectiontypes.IdentifiedConnection{
+			openConnection("connection-5", "07-tendermint-8", "connection-2"),
+			openConnection("connection-7", "07-tendermint-8", "connection-0"),
+		},
+	}
+	cfg := exampleConfig
+	cfg.Lumera.GRPCEndpoint = serveIBCChain(t, lumera)
+	cfg.Controller.GRPCEndpoint = serveIBCChain(t, controller)
+
+	pairs, err := DiscoverConnections(context.Background(), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ConnectionPair{{ConnectionID: "connection-5", CounterpartyConnectionID: "connection-2", ClientID: "07-tendermint-8"}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Fatalf("pairs = %+v, want %+v", pairs, want)
+	}
+
+	// Errors other than NotFound still abort discovery.
+	controller.connErr = status.Error(codes.Unavailable, "node syncing")
+	if _, err := DiscoverConnections(context.Background(), &cfg); status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, want Unavailable", err)
+	}
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	abci "cosmossdk.io/api/tendermint/abci"
+	"github.com/LumeraProtocol/sdk-go/blockchain/base"
+	"github.com/LumeraProtocol/sdk-go/ica"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+)
+
+const defaultPacketPollDelay = 2 * time.Second
+
+// sendPacketFromTx extracts the source route and sequence of the first send_packet event in a tx.
+func sendPacketFromTx(resp *txtypes.GetTxResponse) (ica.PacketInfo, error) {
+	if resp == nil || resp.TxResponse == nil {
+		return ica.PacketInfo{}, fmt.Errorf("nil tx response")
+	}
+	for _, evt := range resp.TxResponse.GetEvents() {
+		if eventType(evt) != "send_packet" {
+			continue
+		}
+		attr := eventAttributes(evt)
+		seqStr := attr["packet_sequence"]
+		port := attr["packet_src_port"]
+		channel := attr["packet_src_channel"]
+		if seqStr == "" || port == "" || channel == "" {
+			continue
+		}
+		seq, err := strconv.ParseUint(seqStr, 10, 64)
+		if err != nil {
+			return ica.PacketInfo{}, fmt.Errorf("parse packet_sequence %q: %w", seqStr, err)
+		}
+		return ica.PacketInfo{Port: port, Channel: channel, Sequence: seq}, nil
+	}
+	return ica.PacketInfo{}, ica.ErrPacketInfoNotFound
+}
+
+// counterpartyRoute resolves the destination port/channel of a controller-side channel.
+func counterpartyRoute(ctx context.Context, bc *base.Client, port, channel string) (string, string, error) {
+	resp, err := channeltypes.NewQueryClient(bc.GRPCConn()).Channel(ctx, &channeltypes.QueryChannelRequest{PortId: port, ChannelId: channel})
+	if err != nil {
+		return "", "", fmt.Errorf("query channel %s/%s: %w", port, channel, err)
+	}
+	ch := resp.GetChannel()
+	if ch == nil || ch.Counterparty.ChannelId == "" {
+		return "", "", fmt.Errorf("channel %s/%s has no counterparty", port, channel)
+	}
+	return ch.Counterparty.PortId, ch.Counterparty.ChannelId, nil
+}
+
+// waitForWriteAck polls the destination chain until a write_acknowledgement for the packet is indexed.
+func waitForWriteAck(ctx context.Context, bc *base.Client, port, channel string, sequence uint64) ([]byte, error) {
+	for {
+		ack, err := queryWriteAck(ctx, bc, port, channel, sequence)
+		if err == nil {
+			return ack, nil
+		}
+		if !errors.Is(err, ica.ErrAckNotFound) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for ack %s/%s/%d: %w", port, channel, sequence, ctx.Err())
+		case <-time.After(defaultPacketPollDelay):
+		}
+	}
+}
+
+// queryWriteAck searches destination-chain txs for the acknowledgement bytes of a packet.
+func queryWriteAck(ctx context.Context, bc *base.Client, port, channel string, sequence uint64) ([]byte, error) {
+	seqStr := strconv.FormatUint(sequence, 10)
+	resp, err := bc.GetTxsByEvents(ctx, []string{
+		fmt.Sprintf("write_acknowledgement.packet_dst_port='%s'", port),
+		fmt.Sprintf("write_acknowledgement.packet_dst_channel='%s'", channel),
+		fmt.Sprintf("write_acknowledgement.packet_sequence='%d'", sequence),
+	}, 1, 5)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range resp.GetTxResponses() {
+		for _, evt := range tx.GetEvents() {
+			if eventType(evt) != "write_acknowledgement" {
+				continue
+			}
+			attr := eventAttributes(evt)
+			if attr["packet_dst_port"] != port || attr["packet_dst_channel"] != channel || attr["packet_sequence"] != seqStr {
+				continue
+			}
+			if v := attr["packet_ack_hex"]; v != "" {
+				ack, err := hex.DecodeString(v)
+				if err != nil {
+					return nil, fmt.Errorf("decode acknowledgement hex: %w", err)
+				}
+				return ack, nil
+			}
+			if v := attr["packet_ack"]; v != "" {
+				return []byte(v), nil
+			}
+		}
+	}
+	return nil, ica.ErrAckNotFound
+}
+
+// queryTimeoutPacket reports whether the source chain processed a timeout for the packet.
+func queryTimeoutPacket(ctx context.Context, bc *base.Client, port, channel string, sequence uint64) (bool, error) {
+	seqStr := strconv.FormatUint(sequence, 10)
+	resp, err := bc.GetTxsByEvents(ctx, []string{
+		fmt.Sprintf("timeout_packet.packet_src_port='%s'", port),
+		fmt.Sprintf("timeout_packet.packet_src_channel='%s'", channel),
+		fmt.Sprintf("timeout_packet.packet_sequence='%d'", sequence),
+	}, 1, 5)
+	if err != nil {
+		return false, err
+	}
+	for _, tx := range resp.GetTxResponses() {
+		for _, evt := range tx.GetEvents() {
+			if eventType(evt) != "timeout_packet" {
+				continue
+			}
+			attr := eventAttributes(evt)
+			if attr["packet_src_port"] == port && attr["packet_src_channel"] == channel && attr["packet_sequence"] == seqStr {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// AckError is an error acknowledgement written by the host chain.
+// Code is the ABCI code reported in the ack, or 0 when it cannot be parsed.
+type AckError struct {
+	Code uint32
+	Log  string
+}
+
+func (e *AckError) Error() string {
+	return fmt.Sprintf("ack error: %s", e.Log)
+}
+
+func (e *AckError) Is(target error) bool { return target == ErrAckError }
+
+// newAckError parses the "ABCI code: N: ..." form ibc-go uses for error acks.
+func newAckError(log string) *AckError {
+	ackErr := &AckError{Log: log}
+	if rest, ok := strings.CutPrefix(log, "ABCI code: "); ok {
+		if num, _, ok := strings.Cut(rest, ":"); ok {
+			if code, err := strconv.ParseUint(strings.TrimSpace(num), 10, 32); err == nil {
+				ackErr.Code = uint32(code)
+			}
+		}
+	}
+	return ackErr
+}
+
+// decodeAck unmarshals a channel acknowledgement and surfaces error acks as *AckError.
+func decodeAck(ackBytes []byte) (*channeltypes.Acknowledgement, error) {
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(ackBytes, &ack); err != nil {
+		return nil, fmt.Errorf("decode acknowledgement: %w", err)
+	}
+	if ack.GetError() != "" {
+		return &ack, newAckError(ack.GetError())
+	}
+	return &ack, nil
+}
+
+func eventType(evt *abci.Event) string {
+	if t := strings.TrimSpace(decodeEventValue(evt.GetType_())); t != "" {
+		return t
+	}
+	return evt.GetType_()
+}
+
+func eventAttributes(evt *abci.Event) map[string]string {
+	attr := make(map[string]string, len(evt.GetAttributes()))
+	for _, a := range evt.GetAttributes() {
+		key := strings.TrimSpace(decodeEventValue(a.GetKey()))
+		if key != "" {
+			attr[key] = strings.TrimSpace(decodeEventValue(a.GetValue()))
+		}
+	}
+	return attr
+}
+
+// decodeEventValue handles both plain and base64-encoded (pre-0.38) event strings.
+func decodeEventValue(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return raw
+	}
+	for _, b := range decoded {
+		if b < 32 || b > 126 {
+			return raw
+		}
+	}
+	return string(decoded)
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/ica"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// ICAMsgResponse is one message response decoded from an ICA ack's TxMsgData.
+// ActionID and Status are set for MsgRequestActionResponse and MsgApproveActionResponse.
+type ICAMsgResponse struct {
+	TypeURL  string
+	ActionID string
+	Status   string
+}
+
+// ICAAck is the outcome of an ICA packet: a result ack, an error ack (Err) or a timeout.
+type ICAAck struct {
+	Ref       *PacketRef
+	TimedOut  bool
+	Err       *AckError
+	Responses []ICAMsgResponse
+}
+
+// ActionIDs returns the action IDs of all decoded responses, in message order.
+func (a *ICAAck) ActionIDs() []string {
+	var ids []string
+	for _, resp := range a.Responses {
+		if resp.ActionID != "" {
+			ids = append(ids, resp.ActionID)
+		}
+	}
+	return ids
+}
+
+// Result returns nil for a result ack, *PacketTimeoutError for a timed-out
+// packet and the wrapped *AckError for an error ack.
+func (a *ICAAck) Result() error {
+	switch {
+	case a.TimedOut:
+		return &PacketTimeoutError{Ref: a.Ref}
+	case a.Err != nil:
+		return fmt.Errorf("tx %s: %w", a.Ref.TxHash, a.Err)
+	}
+	return nil
+}
+
+// PacketRefFromTx looks up a controller tx by hash and returns the ICA packet it sent.
+func (c *Controller) PacketRefFromTx(ctx context.Context, txHash string) (*PacketRef, error) {
+	if c == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	txHash = strings.ToUpper(strings.TrimSpace(txHash))
+	resp, err := c.controllerBC.GetTx(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("query controller tx %s: %w", txHash, err)
+	}
+	return packetRefFromTx(txHash, resp)
+}
+
+// packetRefFromTx reads the ICA packet sent by an included controller tx.
+func packetRefFromTx(txHash string, resp *txtypes.GetTxResponse) (*PacketRef, error) {
+	if code := resp.GetTxResponse().GetCode(); code != 0 {
+		return nil, &TxFailedError{TxHash: txHash, Code: code, Log: resp.GetTxResponse().GetRawLog()}
+	}
+	packet, err := sendPacketFromTx(resp)
+	if err != nil {
+		return nil, fmt.Errorf("tx %s: %w", txHash, err)
+	}
+	return &PacketRef{TxHash: txHash, Port: packet.Port, Channel: packet.Channel, Sequence: packet.Sequence}, nil
+}
+
+// WaitICAAck polls up to ackWaitTimeout for the host acknowledgement or a
+// controller-side timeout of the packet and decodes the outcome.
+// A deadline yields *AckPendingError.
+func (c *Controller) WaitICAAck(ctx context.Context, ref *PacketRef) (*ICAAck, error) {
+	if c == nil || c.controllerBC == nil || c.hostBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("packet reference is nil")
+	}
+	ackCtx, cancel := context.WithTimeout(ctx, c.ackWaitTimeout)
+	defer cancel()
+	start := time.Now()
+	ack, err := c.pollICAAck(ackCtx, ref)
+	if err != nil && errors.Is(ackCtx.Err(), context.DeadlineExceeded) {
+		return nil, &AckPendingError{Ref: ref, Waited: time.Since(start).Round(time.Second)}
+	}
+	return ack, err
+}
+
+// pollICAAck alternates between the host write_acknowledgement and the
+// controller timeout_packet lookups until one is found.
+func (c *Controller) pollICAAck(ctx context.Context, ref *PacketRef) (*ICAAck, error) {
+	hostPort, hostChannel, err := counterpartyRoute(ctx, c.controllerBC, ref.Port, ref.Channel)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		ackBytes, err := queryWriteAck(ctx, c.hostBC, hostPort, hostChannel, ref.Sequence)
+		if err == nil {
+			return decodeICAAck(ref, ackBytes)
+		}
+		if !errors.Is(err, ica.ErrAckNotFound) {
+			return nil, err
+		}
+		timedOut, err := queryTimeoutPacket(ctx, c.controllerBC, ref.Port, ref.Channel, ref.Sequence)
+		if err != nil {
+			return nil, err
+		}
+		if timedOut {
+			return &ICAAck{Ref: ref, TimedOut: true}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for ack %s/%s/%d: %w", hostPort, hostChannel, ref.Sequence, ctx.Err())
+		case <-time.After(defaultPacketPollDelay):
+		}
+	}
+}
+
+// decodeICAAck decodes the ack's TxMsgData into typed action message responses.
+func decodeICAAck(ref *PacketRef, ackBytes []byte) (*ICAAck, error) {
+	out := &ICAAck{Ref: ref}
+	ack, err := decodeAck(ackBytes)
+	if ack == nil {
+		return nil, err
+	}
+	if ackErr, ok := err.(*AckError); ok {
+		out.Err = ackErr
+		return out, nil
+	}
+	var msgData sdk.TxMsgData
+	if err := gogoproto.Unmarshal(ack.GetResult(), &msgData); err != nil {
+		return nil, fmt.Errorf("decode ack tx msg data: %w", err)
+	}
+	for _, packed := range msgData.MsgResponses {
+		if packed == nil {
+			continue
+		}
+		resp := ICAMsgResponse{TypeURL: packed.TypeUrl}
+		switch packed.TypeUrl {
+		case sdk.MsgTypeURL(&actiontypes.MsgRequestActionResponse{}):
+			var msg actiontypes.MsgRequestActionResponse
+			if err := gogoproto.Unmarshal(packed.Value, &msg); err != nil {
+				return nil, fmt.Errorf("decode %s: %w", packed.TypeUrl, err)
+			}
+			resp.ActionID, resp.Status = msg.ActionId, msg.Status
+		case sdk.MsgTypeURL(&actiontypes.MsgApproveActionResponse{}):
+			var msg actiontypes.MsgApproveActionResponse
+			if err := gogoproto.Unmarshal(packed.Value, &msg); err != nil {
+				return nil, fmt.Errorf("decode %s: %w", packed.TypeUrl, err)
+			}
+			resp.ActionID, resp.Status = msg.ActionId, msg.Status
+		}
+		out.Responses = append(out.Responses, resp)
+	}
+	return out, nil
+}
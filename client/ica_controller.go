@@ -2,23 +2,41 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
 	sdkmath "cosmossdk.io/math"
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
 	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/blockchain/base"
 	"github.com/LumeraProtocol/sdk-go/ica"
 	sdktypes "github.com/LumeraProtocol/sdk-go/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const defaultMaxGRPCMsgSize = 10 * 1024 * 1024
+const (
+	defaultMaxGRPCMsgSize   = 10 * 1024 * 1024
+	defaultICAPacketTimeout = 10 * time.Minute
+)
 
 // Controller wraps the sdk-go ICA controller for CLI workflows.
+// It keeps its own controller/host chain clients for queries and txs that the
+// sdk-go controller does not expose (ICS-20 transfers, balances, channels).
 type Controller struct {
-	inner *ica.Controller
+	inner          *ica.Controller
+	controllerBC   *base.Client
+	hostBC         *base.Client
+	cfg            *Config
+	packetTimeout  time.Duration
+	ackWaitTimeout time.Duration
 }
 
 // NewICAController builds a gRPC-backed ICA controller using the provided keyring.
@@ -35,7 +53,11 @@ func NewICAController(ctx context.Context, cfg *Config, kr keyring.Keyring) (*Co
 
 	gasPrice, feeDenom, err := parseGasPrices(cfg.Controller.GasPrices)
 	if err != nil {
-		return nil, fmt.Errorf("parse controller.gas_prices: %w", err)
+		return nil, wrapConfigError("controller.gas_prices", err)
+	}
+	packetTimeout, ackWaitTimeout, err := cfg.Controller.ICATimeouts()
+	if err != nil {
+		return nil, err
 	}
 
 	controllerCfg := blockchain.Config{
@@ -66,12 +88,31 @@ func NewICAController(ctx context.Context, cfg *Config, kr keyring.Keyring) (*Co
 		HostKeyName:              cfg.Lumera.KeyName,
 		ConnectionID:             cfg.Controller.ConnectionID,
 		CounterpartyConnectionID: cfg.Controller.CounterpartyConnectionID,
+		RelativeTimeout:          packetTimeout,
 	})
 	if err != nil {
 		return nil, err
 	}
+	controllerBC, err := base.New(ctx, controllerCfg, kr, cfg.Controller.KeyName)
+	if err != nil {
+		_ = inner.Close()
+		return nil, fmt.Errorf("create controller chain client: %w", err)
+	}
+	hostBC, err := base.New(ctx, hostCfg, kr, cfg.Lumera.KeyName)
+	if err != nil {
+		_ = inner.Close()
+		_ = controllerBC.Close()
+		return nil, fmt.Errorf("create lumera chain client: %w", err)
+	}
 
-	return &Controller{inner: inner}, nil
+	return &Controller{
+		inner:          inner,
+		controllerBC:   controllerBC,
+		hostBC:         hostBC,
+		cfg:            cfg,
+		packetTimeout:  packetTimeout,
+		ackWaitTimeout: ackWaitTimeout,
+	}, nil
 }
 
 // Close releases gRPC connections held by the controller.
@@ -79,7 +120,16 @@ func (c *Controller) Close() error {
 	if c == nil || c.inner == nil {
 		return nil
 	}
-	return c.inner.Close()
+	err := c.inner.Close()
+	for _, bc := range []*base.Client{c.controllerBC, c.hostBC} {
+		if bc == nil {
+			continue
+		}
+		if closeErr := bc.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 // OwnerAddress returns the controller-chain owner address.
@@ -91,6 +141,7 @@ func (c *Controller) OwnerAddress() string {
 }
 
 // AppPubkey returns the controller key public key bytes.
+// Uploads use Client.AppPubkey, which honours a separate [app_key].
 func (c *Controller) AppPubkey() []byte {
 	if c == nil || c.inner == nil {
 		return nil
@@ -101,33 +152,232 @@ func (c *Controller) AppPubkey() []byte {
 // EnsureICAAddress resolves or registers an interchain account address.
 func (c *Controller) EnsureICAAddress(ctx context.Context) (string, error) {
 	if c == nil || c.inner == nil {
-		return "", fmt.Errorf("ica controller is not initialized")
+		return "", ErrControllerNotInitialized
 	}
 	return c.inner.EnsureICAAddress(ctx)
 }
 
 // ICAAddress returns the ICA address if already registered.
+// A missing ICA (empty address or gRPC NotFound) matches ErrICANotRegistered.
 func (c *Controller) ICAAddress(ctx context.Context) (string, error) {
 	if c == nil || c.inner == nil {
-		return "", fmt.Errorf("ica controller is not initialized")
+		return "", ErrControllerNotInitialized
 	}
-	return c.inner.ICAAddress(ctx)
+	addr, err := c.inner.ICAAddress(ctx)
+	if errors.Is(err, ica.ErrICAAddressNotFound) || status.Code(err) == codes.NotFound {
+		return "", fmt.Errorf("%w: owner %s on %s: %w", ErrICANotRegistered, c.OwnerAddress(), c.cfg.Controller.ConnectionID, err)
+	}
+	return addr, err
 }
 
 // SendRequestAction sends a request action over ICA and returns the action result.
 func (c *Controller) SendRequestAction(ctx context.Context, msg *actiontypes.MsgRequestAction) (*sdktypes.ActionResult, error) {
-	if c == nil || c.inner == nil {
-		return nil, fmt.Errorf("ica controller is not initialized")
+	results, err := c.SendRequestActions(ctx, []*actiontypes.MsgRequestAction{msg})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// ChannelClosedError reports that the owner's ICA channel is CLOSED.
+// Ordered ICS-27 channels close after a single packet timeout; the ICA address
+// survives and can be reached again after re-registering on the same port.
+type ChannelClosedError struct {
+	PortID    string
+	ChannelID string
+}
+
+func (e *ChannelClosedError) Error() string {
+	return fmt.Sprintf("ica channel %s/%s is closed (an ordered channel closes after a packet timeout); run `ica reopen` to open a new channel for the same ICA address", e.PortID, e.ChannelID)
+}
+
+// checkChannelOpen fails with *ChannelClosedError when the owner's ICA channel
+// exists but is CLOSED, so sends fail up front with an actionable error.
+func (c *Controller) checkChannelOpen(ctx context.Context) error {
+	ch, err := c.ICAChannel(ctx)
+	if err != nil {
+		return err
+	}
+	if ch != nil && ch.State == channeltypes.CLOSED {
+		return &ChannelClosedError{PortID: ch.PortID, ChannelID: ch.ChannelID}
 	}
-	return c.inner.SendRequestAction(ctx, msg)
+	return nil
 }
 
-// SendApproveAction sends approve messages over ICA and returns the controller tx hash.
+// PacketRef identifies an ICA packet sent by a controller tx.
+// It is enough to resume waiting for the acknowledgement later.
+type PacketRef struct {
+	TxHash   string
+	Port     string
+	Channel  string
+	Sequence uint64
+}
+
+// AckPendingError reports that the host acknowledgement did not arrive within
+// controller.ack_wait_timeout. The packet may still be relayed; Ref is enough to
+// keep waiting later.
+type AckPendingError struct {
+	Ref    *PacketRef
+	Waited time.Duration
+}
+
+func (e *AckPendingError) Error() string {
+	return fmt.Sprintf("ack for ica packet %s/%s/%d (tx %s) not received within %s; the packet is still pending",
+		e.Ref.Port, e.Ref.Channel, e.Ref.Sequence, e.Ref.TxHash, e.Waited)
+}
+
+// PacketTimeoutError reports that an ICA packet timed out on the controller
+// chain, so its messages were never executed on the host.
+type PacketTimeoutError struct {
+	Ref *PacketRef
+}
+
+func (e *PacketTimeoutError) Error() string {
+	return fmt.Sprintf("ica packet %s/%s/%d (tx %s) timed out; its messages were not executed",
+		e.Ref.Port, e.Ref.Channel, e.Ref.Sequence, e.Ref.TxHash)
+}
+
+// TxFailedError reports a controller tx that was included but failed, so the
+// ICA packet it carried was never sent.
+type TxFailedError struct {
+	TxHash string
+	Code   uint32
+	Log    string
+}
+
+func (e *TxFailedError) Error() string {
+	return fmt.Sprintf("controller tx %s failed with code %d: %s", e.TxHash, e.Code, e.Log)
+}
+
+// SendRequestActions packs several request actions into a single ICA MsgSendTx.
+// Results are returned in message order, one per action_id found in the ack.
+func (c *Controller) SendRequestActions(ctx context.Context, msgs []*actiontypes.MsgRequestAction) ([]*sdktypes.ActionResult, error) {
+	txHash, err := c.BroadcastRequestActions(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := c.WaitForPacket(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.AwaitRequestActions(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(msgs) {
+		return nil, fmt.Errorf("ack returned %d action ids for %d messages (tx %s)", len(results), len(msgs), ref.TxHash)
+	}
+	return results, nil
+}
+
+// BroadcastRequestActions broadcasts request actions in one ICA MsgSendTx and
+// returns the controller tx hash as soon as the tx is accepted into the mempool.
+// WaitForPacket then waits for its inclusion.
+func (c *Controller) BroadcastRequestActions(ctx context.Context, msgs []*actiontypes.MsgRequestAction) (string, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return "", ErrControllerNotInitialized
+	}
+	anys := make([]*codectypes.Any, 0, len(msgs))
+	for i, msg := range msgs {
+		if msg == nil {
+			return "", fmt.Errorf("msg %d is nil", i)
+		}
+		packed, err := ica.PackRequestAny(msg)
+		if err != nil {
+			return "", fmt.Errorf("pack msg %d: %w", i, err)
+		}
+		anys = append(anys, packed)
+	}
+	if err := c.checkChannelOpen(ctx); err != nil {
+		return "", err
+	}
+	return c.broadcastICAAnys(ctx, anys)
+}
+
+// WaitForPacket waits for a controller tx to be included and returns the ICA
+// packet it sent. A tx that failed yields *TxFailedError.
+func (c *Controller) WaitForPacket(ctx context.Context, txHash string) (*PacketRef, error) {
+	if c == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	resp, err := c.controllerBC.WaitForTxInclusion(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("wait for tx %s inclusion: %w", txHash, err)
+	}
+	return packetRefFromTx(txHash, resp)
+}
+
+// AwaitRequestActions waits for the acknowledgement of a request-action packet
+// and returns one result per action_id, in message order.
+func (c *Controller) AwaitRequestActions(ctx context.Context, ref *PacketRef) ([]*sdktypes.ActionResult, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	ack, err := c.WaitICAAck(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := ack.Result(); err != nil {
+		return nil, err
+	}
+	ids := ack.ActionIDs()
+	results := make([]*sdktypes.ActionResult, len(ids))
+	for i, id := range ids {
+		results[i] = &sdktypes.ActionResult{ActionID: id, TxHash: ref.TxHash}
+	}
+	return results, nil
+}
+
+// SendApproveAction sends approve messages over ICA and returns the controller tx hash
+// once the host acknowledged the packet without error.
 func (c *Controller) SendApproveAction(ctx context.Context, msg *actiontypes.MsgApproveAction) (string, error) {
-	if c == nil || c.inner == nil {
-		return "", fmt.Errorf("ica controller is not initialized")
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return "", ErrControllerNotInitialized
+	}
+	if msg == nil {
+		return "", fmt.Errorf("msg is nil")
+	}
+	packed, err := ica.PackApproveAny(msg)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkChannelOpen(ctx); err != nil {
+		return "", err
+	}
+	txHash, err := c.broadcastICAAnys(ctx, []*codectypes.Any{packed})
+	if err != nil {
+		return "", err
+	}
+	ref, err := c.WaitForPacket(ctx, txHash)
+	if err != nil {
+		return "", err
+	}
+	ack, err := c.WaitICAAck(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if err := ack.Result(); err != nil {
+		return "", err
+	}
+	return ref.TxHash, nil
+}
+
+// broadcastICAAnys signs and broadcasts a MsgSendTx carrying the given messages
+// and returns the controller tx hash.
+func (c *Controller) broadcastICAAnys(ctx context.Context, anys []*codectypes.Any) (string, error) {
+	packetData, err := ica.BuildICAPacketData(anys)
+	if err != nil {
+		return "", err
+	}
+	msg, err := ica.BuildMsgSendTx(c.OwnerAddress(), c.cfg.Controller.ConnectionID, uint64(c.packetTimeout.Nanoseconds()), packetData)
+	if err != nil {
+		return "", err
+	}
+	txBytes, err := c.controllerBC.BuildAndSignTx(ctx, msg, "")
+	if err != nil {
+		return "", fmt.Errorf("build and sign tx: %w", err)
 	}
-	return c.inner.SendApproveAction(ctx, msg)
+	return c.controllerBC.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
 }
 
 func parseGasPrices(value string) (sdkmath.LegacyDec, string, error) {
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+)
+
+const defaultTransferTimeout = 10 * time.Minute
+
+// FundResult describes an ICS-20 top-up of the interchain account.
+type FundResult struct {
+	TxHash        string
+	Channel       string
+	Sequence      uint64
+	ICAAddress    string
+	BalanceBefore sdk.Coins
+	BalanceAfter  sdk.Coins
+}
+
+// InsufficientBalanceError reports that the ICA cannot cover an action price.
+type InsufficientBalanceError struct {
+	ICAAddress string
+	Denom      string
+	Required   sdkmath.Int
+	Available  sdkmath.Int
+}
+
+// Shortfall returns the amount missing from the ICA balance.
+func (e *InsufficientBalanceError) Shortfall() sdkmath.Int {
+	return e.Required.Sub(e.Available)
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("ica %s balance %s%s is below required %s%s (short %s%s)",
+		e.ICAAddress, e.Available, e.Denom, e.Required, e.Denom, e.Shortfall(), e.Denom)
+}
+
+// ICABalances returns the bank balances of the interchain account on Lumera.
+func (c *Controller) ICABalances(ctx context.Context, icaAddr string) (sdk.Coins, error) {
+	if c == nil || c.hostBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	resp, err := banktypes.NewQueryClient(c.hostBC.GRPCConn()).AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: icaAddr})
+	if err != nil {
+		return nil, fmt.Errorf("query ica balances: %w", err)
+	}
+	return resp.GetBalances(), nil
+}
+
+// CheckICABalance verifies the ICA holds at least price on Lumera.
+// It returns *InsufficientBalanceError when the balance is short.
+func (c *Controller) CheckICABalance(ctx context.Context, icaAddr string, price sdk.Coin) error {
+	balances, err := c.ICABalances(ctx, icaAddr)
+	if err != nil {
+		return err
+	}
+	available := balances.AmountOf(price.Denom)
+	if available.LT(price.Amount) {
+		return &InsufficientBalanceError{
+			ICAAddress: icaAddr,
+			Denom:      price.Denom,
+			Required:   price.Amount,
+			Available:  available,
+		}
+	}
+	return nil
+}
+
+// FundICA sends an ICS-20 transfer from the controller owner to the ICA on Lumera.
+// It waits for the packet acknowledgement and for the ICA to hold the transferred
+// amount of the denom Lumera credits for it.
+// An empty channel selects the open transfer channel on controller.connection_id.
+func (c *Controller) FundICA(ctx context.Context, amount sdk.Coin, channel string) (*FundResult, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	if !amount.IsValid() || amount.IsZero() {
+		return nil, fmt.Errorf("invalid transfer amount %q", amount.String())
+	}
+	icaAddr, err := c.ICAAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	channel, err = c.resolveTransferChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := c.transferDenom(ctx, amount.Denom)
+	if err != nil {
+		return nil, err
+	}
+	before, err := c.ICABalances(ctx, icaAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build, sign and broadcast MsgTransfer with the controller key.
+	timeout := uint64(time.Now().Add(defaultTransferTimeout).UnixNano())
+	msg := transfertypes.NewMsgTransfer(transfertypes.PortID, channel, amount, c.OwnerAddress(), icaAddr, clienttypes.ZeroHeight(), timeout, "")
+	txBytes, err := c.controllerBC.BuildAndSignTx(ctx, msg, "")
+	if err != nil {
+		return nil, fmt.Errorf("build and sign transfer: %w", err)
+	}
+	txHash, err := c.controllerBC.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+	if err != nil {
+		return nil, err
+	}
+	txResp, err := c.controllerBC.WaitForTxInclusion(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("wait for transfer inclusion: %w", err)
+	}
+	if code := txResp.GetTxResponse().GetCode(); code != 0 {
+		return nil, fmt.Errorf("transfer tx %s failed with code %d: %s", txHash, code, txResp.GetTxResponse().GetRawLog())
+	}
+	packet, err := sendPacketFromTx(txResp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the host-side acknowledgement, then for the funds to land.
+	hostPort, hostChannel, err := counterpartyRoute(ctx, c.controllerBC, packet.Port, packet.Channel)
+	if err != nil {
+		return nil, err
+	}
+	ackBytes, err := waitForWriteAck(ctx, c.hostBC, hostPort, hostChannel, packet.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decodeAck(ackBytes); err != nil {
+		return nil, fmt.Errorf("transfer %s: %w", txHash, err)
+	}
+	received := receivedDenom(sent, packet.Port, packet.Channel, hostPort, hostChannel)
+	after, err := c.waitForBalance(ctx, icaAddr, sdk.NewCoin(received, before.AmountOf(received).Add(amount.Amount)))
+	if err != nil {
+		return nil, err
+	}
+	return &FundResult{
+		TxHash:        txHash,
+		Channel:       packet.Channel,
+		Sequence:      packet.Sequence,
+		ICAAddress:    icaAddr,
+		BalanceBefore: before,
+		BalanceAfter:  after,
+	}, nil
+}
+
+// EnsureICAFunded applies the [funding] policy: when the ICA balance is below
+// funding.min_balance it transfers enough funding.source_denom from the controller
+// owner to reach funding.target_balance. It returns nil when no top-up was needed.
+func (c *Controller) EnsureICAFunded(ctx context.Context, icaAddr string) (*FundResult, error) {
+	if c == nil || c.cfg == nil || !c.cfg.Funding.Enabled() {
+		return nil, nil
+	}
+	minBalance, targetBalance, err := c.cfg.Funding.Thresholds()
+	if err != nil {
+		return nil, err
+	}
+	balances, err := c.ICABalances(ctx, icaAddr)
+	if err != nil {
+		return nil, err
+	}
+	current := balances.AmountOf(minBalance.Denom)
+	if current.GTE(minBalance.Amount) {
+		return nil, nil
+	}
+	amount := sdk.NewCoin(strings.TrimSpace(c.cfg.Funding.SourceDenom), targetBalance.Amount.Sub(current))
+	res, err := c.FundICA(ctx, amount, c.cfg.Funding.TransferChannel)
+	if err != nil {
+		return nil, fmt.Errorf("top up ica: %w", err)
+	}
+	return res, nil
+}
+
+// resolveTransferChannel validates an explicit channel or picks the open
+// transfer channel on the configured controller connection.
+func (c *Controller) resolveTransferChannel(ctx context.Context, channel string) (string, error) {
+	query := channeltypes.NewQueryClient(c.controllerBC.GRPCConn())
+	channel = strings.TrimSpace(channel)
+	if channel != "" {
+		resp, err := query.Channel(ctx, &channeltypes.QueryChannelRequest{PortId: transfertypes.PortID, ChannelId: channel})
+		if err != nil {
+			return "", fmt.Errorf("query transfer channel %s: %w", channel, err)
+		}
+		ch := resp.GetChannel()
+		if ch == nil || ch.State != channeltypes.OPEN {
+			return "", fmt.Errorf("transfer channel %s is not open", channel)
+		}
+		return channel, nil
+	}
+	connectionID := c.cfg.Controller.ConnectionID
+	channels, err := listConnectionChannels(ctx, c.controllerBC.GRPCConn(), connectionID)
+	if err != nil {
+		return "", err
+	}
+	for _, ch := range channels {
+		if ch.PortId == transfertypes.PortID && ch.State == channeltypes.OPEN {
+			return ch.ChannelId, nil
+		}
+	}
+	return "", fmt.Errorf("no open transfer channel on %s; pass a channel explicitly", connectionID)
+}
+
+// transferDenom returns the ICS-20 denom of a controller-chain coin denom,
+// resolving ibc/ vouchers to their trace on the controller chain.
+func (c *Controller) transferDenom(ctx context.Context, denom string) (transfertypes.Denom, error) {
+	if !strings.HasPrefix(denom, "ibc/") {
+		return transfertypes.NewDenom(denom), nil
+	}
+	resp, err := transfertypes.NewQueryClient(c.controllerBC.GRPCConn()).Denom(ctx, &transfertypes.QueryDenomRequest{Hash: denom})
+	if err != nil {
+		return transfertypes.Denom{}, fmt.Errorf("query denom trace of %s: %w", denom, err)
+	}
+	if resp.GetDenom() == nil {
+		return transfertypes.Denom{}, fmt.Errorf("no denom trace for %s on the controller chain", denom)
+	}
+	return *resp.GetDenom(), nil
+}
+
+// receivedDenom returns the denom the receiving chain credits for sent, as the
+// ICS-20 module does: a token returning over the channel it arrived on loses
+// that hop, any other token gains the receiving port and channel.
+func receivedDenom(sent transfertypes.Denom, srcPort, srcChannel, dstPort, dstChannel string) string {
+	if sent.HasPrefix(srcPort, srcChannel) {
+		return transfertypes.NewDenom(sent.Base, sent.Trace[1:]...).IBCDenom()
+	}
+	trace := append([]transfertypes.Hop{transfertypes.NewHop(dstPort, dstChannel)}, sent.Trace...)
+	return transfertypes.NewDenom(sent.Base, trace...).IBCDenom()
+}
+
+// waitForBalance polls the ICA balance until it holds at least want.
+func (c *Controller) waitForBalance(ctx context.Context, icaAddr string, want sdk.Coin) (sdk.Coins, error) {
+	for {
+		balances, err := c.ICABalances(ctx, icaAddr)
+		if err != nil {
+			return nil, err
+		}
+		if balances.AmountOf(want.Denom).GTE(want.Amount) {
+			return balances, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for ica balance of %s: %w", want, ctx.Err())
+		case <-time.After(defaultPacketPollDelay):
+		}
+	}
+}
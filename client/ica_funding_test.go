@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	"google.golang.org/grpc"
+)
+
+func TestReceivedDenom(t *testing.T) {
+	ulumeOnOsmosis := transfertypes.NewDenom("ulume", transfertypes.NewHop("transfer", "channel-7"))
+	cases := []struct {
+		name string
+		sent transfertypes.Denom
+		want string
+	}{
+		{"returning voucher", ulumeOnOsmosis, "ulume"},
+		{"native token", transfertypes.NewDenom("uosmo"), transfertypes.NewDenom("uosmo", transfertypes.NewHop("transfer", "channel-2")).IBCDenom()},
+		{
+			"voucher from another channel",
+			transfertypes.NewDenom("uatom", transfertypes.NewHop("transfer", "channel-0")),
+			transfertypes.NewDenom("uatom", transfertypes.NewHop("transfer", "channel-2"), transfertypes.NewHop("transfer", "channel-0")).IBCDenom(),
+		},
+		{
+			"multi-hop voucher",
+			transfertypes.NewDenom("ulume", transfertypes.NewHop("transfer", "channel-7"), transfertypes.NewHop("transfer", "channel-9")),
+			transfertypes.NewDenom("ulume", transfertypes.NewHop("transfer", "channel-9")).IBCDenom(),
+		},
+	}
+	for _, tc := range cases {
+		if got := receivedDenom(tc.sent, "transfer", "channel-7", "transfer", "channel-2"); got != tc.want {
+			t.Errorf("%s: received denom = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+// bankQueryServer answers AllBalances with fixed balances.
+type bankQueryServer struct {
+	banktypes.UnimplementedQueryServer
+	balances sdk.Coins
+}
+
+func (s *bankQueryServer) AllBalances(context.Context, *banktypes.QueryAllBalancesRequest) (*banktypes.QueryAllBalancesResponse, error) {
+	return &banktypes.QueryAllBalancesResponse{Balances: s.balances}, nil
+}
+
+func TestWaitForBalance(t *testing.T) {
+	bank := &bankQueryServer{balances: sdk.NewCoins(sdk.NewInt64Coin("ulume", 1500), sdk.NewInt64Coin("uosmo", 7))}
+	c := newTestController(t, func(gs *grpc.Server) { banktypes.RegisterQueryServer(gs, bank) })
+
+	balances, err := c.waitForBalance(context.Background(), "lumera1ica", sdk.NewInt64Coin("ulume", 1500))
+	if err != nil || !balances.Equal(bank.balances) {
+		t.Fatalf("balances = %s, err = %v; want %s", balances, err, bank.balances)
+	}
+
+	// Other denoms, or too little of the funded one, do not end the wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.waitForBalance(ctx, "lumera1ica", sdk.NewCoin("ulume", sdkmath.NewInt(2000)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the wait to run until the deadline", err)
+	}
+}
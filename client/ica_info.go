@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	txtypes "cosmossdk.io/api/cosmos/tx/v1beta1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	controllertypes "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/types"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+)
+
+// ICAChannel describes the controller-side ICA channel and its host counterpart.
+type ICAChannel struct {
+	PortID                string
+	ChannelID             string
+	CounterpartyPortID    string
+	CounterpartyChannelID string
+	State                 channeltypes.State
+	Ordering              channeltypes.Order
+	Version               string
+}
+
+// ICAInfo summarizes the interchain account owned by the controller key.
+// Channel and Balances are nil when the ICA has no channel or address yet.
+type ICAInfo struct {
+	ICAAddress       string
+	OwnerAddress     string
+	ConnectionID     string
+	HostConnectionID string
+	Channel          *ICAChannel
+	Balances         sdk.Coins
+}
+
+// RegisterOptions customizes MsgRegisterInterchainAccount.
+// An empty Version uses the default ICS-27 metadata for the connection pair.
+type RegisterOptions struct {
+	Ordering channeltypes.Order
+	Version  string
+}
+
+// ParseOrdering maps "ordered"/"unordered" to a channel ordering.
+func ParseOrdering(value string) (channeltypes.Order, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "ordered":
+		return channeltypes.ORDERED, nil
+	case "unordered":
+		return channeltypes.UNORDERED, nil
+	default:
+		return channeltypes.NONE, fmt.Errorf("ordering must be one of: ordered, unordered (got %q)", value)
+	}
+}
+
+// Info reports the ICA address, connection/channel identifiers, channel state
+// and Lumera balances. Missing pieces are left empty rather than failing.
+func (c *Controller) Info(ctx context.Context) (*ICAInfo, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	info := &ICAInfo{
+		OwnerAddress: c.OwnerAddress(),
+		ConnectionID: c.cfg.Controller.ConnectionID,
+	}
+	hostConnectionID, err := c.hostConnectionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info.HostConnectionID = hostConnectionID
+	addr, err := c.ICAAddress(ctx)
+	if err != nil && !errors.Is(err, ErrICANotRegistered) {
+		return nil, err
+	}
+	info.ICAAddress = addr
+	info.Channel, err = c.ICAChannel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if addr != "" {
+		info.Balances, err = c.ICABalances(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// ICAChannel returns the ICA channel for the owner on controller.connection_id.
+// An OPEN channel is preferred; otherwise the most recently created one is returned.
+// It returns nil when no channel exists for the owner's port.
+func (c *Controller) ICAChannel(ctx context.Context) (*ICAChannel, error) {
+	if c == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	portID, err := icatypes.NewControllerPortID(c.OwnerAddress())
+	if err != nil {
+		return nil, err
+	}
+	connectionID := c.cfg.Controller.ConnectionID
+	channels, err := listConnectionChannels(ctx, c.controllerBC.GRPCConn(), connectionID)
+	if err != nil {
+		return nil, err
+	}
+	var found *channeltypes.IdentifiedChannel
+	var foundSeq uint64
+	for _, ch := range channels {
+		if ch.PortId != portID {
+			continue
+		}
+		seq, _ := channeltypes.ParseChannelSequence(ch.ChannelId)
+		switch {
+		case found == nil,
+			ch.State == channeltypes.OPEN && found.State != channeltypes.OPEN,
+			(ch.State == channeltypes.OPEN) == (found.State == channeltypes.OPEN) && seq > foundSeq:
+			found, foundSeq = ch, seq
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	return &ICAChannel{
+		PortID:                found.PortId,
+		ChannelID:             found.ChannelId,
+		CounterpartyPortID:    found.Counterparty.PortId,
+		CounterpartyChannelID: found.Counterparty.ChannelId,
+		State:                 found.State,
+		Ordering:              found.Ordering,
+		Version:               found.Version,
+	}, nil
+}
+
+// RegisterICA sends MsgRegisterInterchainAccount and waits until the ICA address
+// is available and its channel is OPEN. It returns the resulting ICA info.
+func (c *Controller) RegisterICA(ctx context.Context, opts RegisterOptions) (*ICAInfo, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	if opts.Ordering == channeltypes.NONE {
+		opts.Ordering = channeltypes.ORDERED
+	}
+	version := strings.TrimSpace(opts.Version)
+	if version == "" {
+		hostConnectionID, err := c.hostConnectionID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		version = icatypes.NewDefaultMetadataString(c.cfg.Controller.ConnectionID, hostConnectionID)
+	}
+	msg := controllertypes.NewMsgRegisterInterchainAccount(c.cfg.Controller.ConnectionID, c.OwnerAddress(), version, opts.Ordering)
+	txBytes, err := c.controllerBC.BuildAndSignTx(ctx, msg, "")
+	if err != nil {
+		return nil, fmt.Errorf("build and sign tx: %w", err)
+	}
+	txHash, err := c.controllerBC.Broadcast(ctx, txBytes, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+	if err != nil {
+		return nil, err
+	}
+	txResp, err := c.controllerBC.WaitForTxInclusion(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("wait for tx %s inclusion: %w", txHash, err)
+	}
+	if code := txResp.GetTxResponse().GetCode(); code != 0 {
+		return nil, fmt.Errorf("register tx %s failed with code %d: %s", txHash, code, txResp.GetTxResponse().GetRawLog())
+	}
+	return c.WaitForOpenChannel(ctx)
+}
+
+// ReopenICA re-registers the ICA on the same controller port after its channel
+// closed and waits for the new channel to open. Ordering NONE and an empty Version
+// reuse the closed channel's values. The ICA address must stay the same.
+func (c *Controller) ReopenICA(ctx context.Context, opts RegisterOptions) (*ICAInfo, error) {
+	if c == nil || c.inner == nil || c.controllerBC == nil {
+		return nil, ErrControllerNotInitialized
+	}
+	ch, err := c.ICAChannel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ch == nil {
+		return nil, fmt.Errorf("no ica channel found for %s on %s; run `ica register` instead", c.OwnerAddress(), c.cfg.Controller.ConnectionID)
+	}
+	if ch.State != channeltypes.CLOSED {
+		return nil, fmt.Errorf("ica channel %s/%s is %s; only a closed channel can be reopened", ch.PortID, ch.ChannelID, ch.State)
+	}
+	prevAddr, err := c.ICAAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Ordering == channeltypes.NONE {
+		opts.Ordering = ch.Ordering
+	}
+	if strings.TrimSpace(opts.Version) == "" {
+		opts.Version = ch.Version
+	}
+	info, err := c.RegisterICA(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if info.ICAAddress != prevAddr {
+		return nil, fmt.Errorf("ica address changed after reopen: was %s, now %s", prevAddr, info.ICAAddress)
+	}
+	return info, nil
+}
+
+// WaitForOpenChannel polls until the ICA address resolves and the channel is
+// OPEN, e.g. to follow a channel handshake that is still in INIT or TRYOPEN.
+func (c *Controller) WaitForOpenChannel(ctx context.Context) (*ICAInfo, error) {
+	for {
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if info.ICAAddress != "" && info.Channel != nil && info.Channel.State == channeltypes.OPEN {
+			return info, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for ica channel to open: %w", ctx.Err())
+		case <-time.After(defaultPacketPollDelay):
+		}
+	}
+}
+
+// hostConnectionID returns controller.counterparty_connection_id or queries it from the connection.
+func (c *Controller) hostConnectionID(ctx context.Context) (string, error) {
+	if id := strings.TrimSpace(c.cfg.Controller.CounterpartyConnectionID); id != "" {
+		return id, nil
+	}
+	connectionID := c.cfg.Controller.ConnectionID
+	resp, err := connectiontypes.NewQueryClient(c.controllerBC.GRPCConn()).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return "", fmt.Errorf("query ibc connection %s: %w", connectionID, err)
+	}
+	if resp.GetConnection() == nil {
+		return "", fmt.Errorf("ibc connection %s is empty", connectionID)
+	}
+	return resp.GetConnection().Counterparty.ConnectionId, nil
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdktypes "github.com/LumeraProtocol/sdk-go/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultJournalFile is the journal file name created next to the config file.
+const DefaultJournalFile = "journal.db"
+
+var uploadsBucket = []byte("uploads")
+
+// UploadStep names the last completed step of a journaled upload.
+type UploadStep string
+
+const (
+	UploadStepStarted    UploadStep = "started"
+	UploadStepSubmitted  UploadStep = "submitted"
+	UploadStepBroadcast  UploadStep = "broadcast"
+	UploadStepRegistered UploadStep = "registered"
+	UploadStepUploaded   UploadStep = "uploaded"
+)
+
+// UploadOutcome records how a journaled action ended; empty means unfinished.
+type UploadOutcome string
+
+const (
+	UploadOutcomeDone     UploadOutcome = "done"
+	UploadOutcomeApproved UploadOutcome = "approved"
+	UploadOutcomeFailed   UploadOutcome = "failed"
+	UploadOutcomeLost     UploadOutcome = "lost"
+)
+
+// UploadRecord tracks one file upload; fields are filled as each step completes.
+// MsgIndex is the position of the file's MsgRequestAction in its MsgSendTx,
+// non-zero only for batch uploads.
+type UploadRecord struct {
+	FileHash       string        `json:"file_hash"`
+	FilePath       string        `json:"file_path"`
+	ICAAddress     string        `json:"ica_address"`
+	Public         bool          `json:"public"`
+	TxHash         string        `json:"tx_hash,omitempty"`
+	PacketPort     string        `json:"packet_port,omitempty"`
+	PacketChannel  string        `json:"packet_channel,omitempty"`
+	PacketSequence uint64        `json:"packet_sequence,omitempty"`
+	MsgIndex       int           `json:"msg_index,omitempty"`
+	ActionID       string        `json:"action_id,omitempty"`
+	TaskID         string        `json:"task_id,omitempty"`
+	Outcome        UploadOutcome `json:"outcome,omitempty"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// Step derives the last completed step from the recorded fields.
+func (r *UploadRecord) Step() UploadStep {
+	switch {
+	case r.TaskID != "":
+		return UploadStepUploaded
+	case r.ActionID != "":
+		return UploadStepRegistered
+	case r.TxHash != "" && r.PacketSequence != 0:
+		return UploadStepBroadcast
+	case r.TxHash != "":
+		return UploadStepSubmitted
+	default:
+		return UploadStepStarted
+	}
+}
+
+// Finished reports whether the record reached a final outcome.
+func (r *UploadRecord) Finished() bool {
+	return r.Outcome != ""
+}
+
+// Incomplete reports whether an upload of the file stopped before its bytes
+// reached the supernodes, so rerunning upload should resume it.
+func (r *UploadRecord) Incomplete() bool {
+	return !r.Finished() && r.Step() != UploadStepUploaded
+}
+
+// PacketRef returns the recorded ICA packet, or nil when none was broadcast.
+func (r *UploadRecord) PacketRef() *PacketRef {
+	if r.TxHash == "" || r.PacketSequence == 0 {
+		return nil
+	}
+	return &PacketRef{TxHash: r.TxHash, Port: r.PacketPort, Channel: r.PacketChannel, Sequence: r.PacketSequence}
+}
+
+// SetActionID records the action ID for this record's message among the
+// results of its MsgSendTx ack.
+func (r *UploadRecord) SetActionID(results []*sdktypes.ActionResult) error {
+	if r.MsgIndex >= len(results) {
+		return fmt.Errorf("ack for tx %s has %d action ids; record expects message %d", r.TxHash, len(results), r.MsgIndex)
+	}
+	r.ActionID = results[r.MsgIndex].ActionID
+	return nil
+}
+
+// SetPacket records the controller tx and ICA packet of the registration.
+func (r *UploadRecord) SetPacket(ref *PacketRef) {
+	r.TxHash = ref.TxHash
+	r.PacketPort = ref.Port
+	r.PacketChannel = ref.Channel
+	r.PacketSequence = ref.Sequence
+}
+
+// Journal is an on-disk record of uploads keyed by file hash.
+// It lets an interrupted upload resume from its last completed step.
+type Journal struct {
+	db *bolt.DB
+}
+
+// DefaultJournalPath places the journal next to the given config file.
+func DefaultJournalPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), DefaultJournalFile)
+}
+
+// ProfileJournalPath places a per-profile journal ("journal.<profile>.db") next
+// to the config file, so each profile's ICA keeps its own records. An empty
+// profile uses DefaultJournalPath.
+func ProfileJournalPath(configPath, profile string) string {
+	if profile == "" {
+		return DefaultJournalPath(configPath)
+	}
+	ext := filepath.Ext(DefaultJournalFile)
+	name := strings.TrimSuffix(DefaultJournalFile, ext) + "." + profile + ext
+	return filepath.Join(filepath.Dir(configPath), name)
+}
+
+// OpenJournal opens or creates the journal file at path.
+func OpenJournal(path string) (*Journal, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open journal %s: %w", path, err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(uploadsBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("init journal %s: %w", path, err)
+	}
+	return &Journal{db: db}, nil
+}
+
+// Close releases the journal file lock.
+func (j *Journal) Close() error {
+	if j == nil || j.db == nil {
+		return nil
+	}
+	return j.db.Close()
+}
+
+// Get returns the record for a file hash, or nil when none exists.
+func (j *Journal) Get(fileHash string) (*UploadRecord, error) {
+	var rec *UploadRecord
+	err := j.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(uploadsBucket).Get([]byte(fileHash))
+		if data == nil {
+			return nil
+		}
+		rec = &UploadRecord{}
+		return json.Unmarshal(data, rec)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read journal record %s: %w", fileHash, err)
+	}
+	return rec, nil
+}
+
+// Put stores a record, stamping UpdatedAt. Each call is committed to disk.
+func (j *Journal) Put(rec *UploadRecord) error {
+	if rec == nil || rec.FileHash == "" {
+		return fmt.Errorf("journal record requires a file hash")
+	}
+	rec.UpdatedAt = time.Now().UTC()
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err := j.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(uploadsBucket).Put([]byte(rec.FileHash), data)
+	}); err != nil {
+		return fmt.Errorf("write journal record %s: %w", rec.FileHash, err)
+	}
+	return nil
+}
+
+// List returns all records in key order.
+func (j *Journal) List() ([]*UploadRecord, error) {
+	var recs []*UploadRecord
+	err := j.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(uploadsBucket).ForEach(func(_, data []byte) error {
+			rec := &UploadRecord{}
+			if err := json.Unmarshal(data, rec); err != nil {
+				return err
+			}
+			recs = append(recs, rec)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list journal records: %w", err)
+	}
+	return recs, nil
+}
+
+// FindByActionID returns the record registered under an action ID, or nil when none exists.
+func (j *Journal) FindByActionID(actionID string) (*UploadRecord, error) {
+	recs, err := j.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range recs {
+		if rec.ActionID == actionID {
+			return rec, nil
+		}
+	}
+	return nil, nil
+}
+
+// HashFile returns the hex-encoded SHA-256 of a file's contents.
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,333 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// LumeraHRP is the bech32 prefix of Lumera account addresses.
+const LumeraHRP = "lumera"
+
+// KeyringKey describes one key in the controller keyring.
+// KeyType is "cosmos" or "evm", or empty for other signing algorithms; the
+// addresses are only derived for those two.
+type KeyringKey struct {
+	Name          string
+	Algo          string
+	KeyType       string
+	Address       string
+	LumeraAddress string
+	PubKey        []byte
+}
+
+// OpenControllerKeyring opens the keyring configured in the [controller] section,
+// or a keyring backed by the remote signer when controller.signer = "remote".
+func OpenControllerKeyring(cfg ControllerConfig) (keyring.Keyring, error) {
+	return newControllerKeyring(cfg)
+}
+
+// ListKeys returns the keys in kr sorted by name, with addresses under hrp.
+func ListKeys(kr keyring.Keyring, hrp string) ([]KeyringKey, error) {
+	records, err := kr.List()
+	if err != nil {
+		return nil, fmt.Errorf("list keyring keys: %w", err)
+	}
+	keys := make([]KeyringKey, 0, len(records))
+	for _, rec := range records {
+		key, err := describeKey(kr, rec, hrp)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// ShowKey describes the named key, with its address under hrp.
+func ShowKey(kr keyring.Keyring, name, hrp string) (KeyringKey, error) {
+	rec, err := kr.Key(name)
+	if err != nil {
+		return KeyringKey{}, fmt.Errorf("%w: %q: %w", ErrKeyNotFound, name, err)
+	}
+	return describeKey(kr, rec, hrp)
+}
+
+// describeKey fills a KeyringKey from a keyring record.
+func describeKey(kr keyring.Keyring, rec *keyring.Record, hrp string) (KeyringKey, error) {
+	pub, err := rec.GetPubKey()
+	if err != nil {
+		return KeyringKey{}, fmt.Errorf("get pubkey for %q: %w", rec.Name, err)
+	}
+	key := KeyringKey{Name: rec.Name, Algo: pub.Type(), PubKey: pub.Bytes()}
+	for _, kt := range []sdkcrypto.KeyType{sdkcrypto.KeyTypeCosmos, sdkcrypto.KeyTypeEVM} {
+		if string(kt.SigningAlgo().Name()) == key.Algo {
+			key.KeyType = kt.String()
+		}
+	}
+	if key.KeyType == "" {
+		return key, nil
+	}
+	if key.Address, err = sdkcrypto.AddressFromKey(kr, rec.Name, hrp); err != nil {
+		return KeyringKey{}, fmt.Errorf("derive address for %q: %w", rec.Name, err)
+	}
+	if key.LumeraAddress, err = sdkcrypto.AddressFromKey(kr, rec.Name, LumeraHRP); err != nil {
+		return KeyringKey{}, fmt.Errorf("derive lumera address for %q: %w", rec.Name, err)
+	}
+	return key, nil
+}
+
+// CreateKey generates a new key of keyType ("cosmos" or "evm") and returns its
+// mnemonic. An existing key with the same name is never replaced.
+func CreateKey(kr keyring.Keyring, name, keyType string) (string, error) {
+	kt, err := ParseKeyType(keyType)
+	if err != nil {
+		return "", err
+	}
+	if err := ensureNoKey(kr, name); err != nil {
+		return "", err
+	}
+	_, mnemonic, err := kr.NewMnemonic(name, keyring.English, kt.HDPath(), keyring.DefaultBIP39Passphrase, kt.SigningAlgo())
+	if err != nil {
+		return "", fmt.Errorf("create key %q: %w", name, err)
+	}
+	return mnemonic, nil
+}
+
+// RecoverKey derives a key of keyType from mnemonic and stores it under name.
+func RecoverKey(kr keyring.Keyring, name, mnemonic, keyType string) error {
+	kt, err := ParseKeyType(keyType)
+	if err != nil {
+		return err
+	}
+	if err := ensureNoKey(kr, name); err != nil {
+		return err
+	}
+	if _, err := kr.NewAccount(name, strings.TrimSpace(mnemonic), keyring.DefaultBIP39Passphrase, kt.HDPath(), kt.SigningAlgo()); err != nil {
+		return fmt.Errorf("recover key %q: %w", name, err)
+	}
+	return nil
+}
+
+// ImportArmoredKey imports an ASCII-armored private key (as written by
+// ExportArmoredKey or "<chain>d keys export") under name. Armor only carries
+// cosmos keys. When keyType is set, a key of another algorithm is removed
+// again and reported as a mismatch.
+func ImportArmoredKey(kr keyring.Keyring, name, armor, passphrase, keyType string) error {
+	kt, err := ParseKeyType(keyType)
+	if err != nil {
+		return err
+	}
+	if kt == sdkcrypto.KeyTypeEVM {
+		return fmt.Errorf("armored keys are cosmos keys; import evm key %q from hex", name)
+	}
+	if err := ensureNoKey(kr, name); err != nil {
+		return err
+	}
+	if err := kr.ImportPrivKey(name, armor, passphrase); err != nil {
+		return fmt.Errorf("import key %q: %w", name, err)
+	}
+	if keyType == "" {
+		return nil
+	}
+	if err := validateKeyType(kr, name, keyType); err != nil {
+		_ = kr.Delete(name)
+		return err
+	}
+	return nil
+}
+
+// ImportKeyHex imports a hex-encoded raw private key of keyType under name,
+// as written by ExportKeyHex or "<chain>d keys export --unarmored-hex".
+func ImportKeyHex(kr keyring.Keyring, name, privHex, keyType string) error {
+	kt, err := ParseKeyType(keyType)
+	if err != nil {
+		return err
+	}
+	if err := ensureNoKey(kr, name); err != nil {
+		return err
+	}
+	if err := kr.ImportPrivKeyHex(name, strings.TrimSpace(privHex), string(kt.SigningAlgo().Name())); err != nil {
+		return fmt.Errorf("import key %q: %w", name, err)
+	}
+	return nil
+}
+
+// ExportArmoredKey returns the named cosmos private key ASCII-armored and
+// encrypted with passphrase. evm keys have no portable armor encoding; use
+// ExportKeyHex for them.
+func ExportArmoredKey(kr keyring.Keyring, name, passphrase string) (string, error) {
+	key, err := ShowKey(kr, name, LumeraHRP)
+	if err != nil {
+		return "", err
+	}
+	if key.KeyType == sdkcrypto.KeyTypeEVM.String() {
+		return "", fmt.Errorf("key %q is an evm key; armored export only supports cosmos keys, export it as unarmored hex", name)
+	}
+	armor, err := kr.ExportPrivKeyArmor(name, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("export key %q: %w", name, err)
+	}
+	return armor, nil
+}
+
+// ExportKeyHex returns the named private key as unencrypted hex.
+func ExportKeyHex(kr keyring.Keyring, name string) (string, error) {
+	if _, err := kr.Key(name); err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrKeyNotFound, name, err)
+	}
+	exporter, ok := kr.(interface {
+		ExportPrivateKeyObject(uid string) (cryptotypes.PrivKey, error)
+	})
+	if !ok {
+		return "", fmt.Errorf("keyring does not support private key export")
+	}
+	priv, err := exporter.ExportPrivateKeyObject(name)
+	if err != nil {
+		return "", fmt.Errorf("export key %q: %w", name, err)
+	}
+	return hex.EncodeToString(priv.Bytes()), nil
+}
+
+// DeleteKey removes the named key from kr.
+func DeleteKey(kr keyring.Keyring, name string) error {
+	if _, err := kr.Key(name); err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrKeyNotFound, name, err)
+	}
+	if err := kr.Delete(name); err != nil {
+		return fmt.Errorf("delete key %q: %w", name, err)
+	}
+	return nil
+}
+
+// ensureNoKey fails when name is already taken in kr.
+func ensureNoKey(kr keyring.Keyring, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("key name is required")
+	}
+	if _, err := kr.Key(name); err == nil {
+		return fmt.Errorf("%w: %q", ErrKeyExists, name)
+	}
+	return nil
+}
+
+// ValidateKeys checks that controller.key_name and lumera.key_name exist in kr
+// and match their configured key types.
+func ValidateKeys(kr keyring.Keyring, cfg *Config) error {
+	if err := validateKeyType(kr, cfg.Controller.KeyName, cfg.Controller.KeyType); err != nil {
+		return fmt.Errorf("controller key type: %w", err)
+	}
+	if err := validateKeyType(kr, cfg.Lumera.KeyName, cfg.Lumera.KeyType); err != nil {
+		return fmt.Errorf("lumera key type: %w", err)
+	}
+	return nil
+}
+
+// AppKeyName returns the key that signs Cascade metadata: app_key.key_name
+// when [app_key] is set, otherwise controller.key_name.
+func (c *Config) AppKeyName() string {
+	if c.AppKey.Enabled() {
+		return c.AppKey.KeyName
+	}
+	return c.Controller.KeyName
+}
+
+// OpenAppKeyring returns the keyring holding the app key: controllerKR unless
+// [app_key] selects its own keyring backend.
+func OpenAppKeyring(cfg *Config, controllerKR keyring.Keyring) (keyring.Keyring, error) {
+	if !cfg.AppKey.Enabled() || !cfg.AppKey.SeparateKeyring() {
+		return controllerKR, nil
+	}
+	// Reuse the controller keyring app name so "os" backend entries line up.
+	kr, err := openKeyring(keyringAppName(cfg.Controller), cfg.AppKey.KeyringBackend, cfg.AppKey.KeyringDir, cfg.AppKey.passphraseSource())
+	if err != nil {
+		return nil, fmt.Errorf("app key: %w", err)
+	}
+	return kr, nil
+}
+
+// ValidateAppKey checks that app_key.key_name exists in appKR and matches
+// app_key.key_type. It is a no-op when [app_key] is not set.
+func ValidateAppKey(appKR keyring.Keyring, cfg *Config) error {
+	if !cfg.AppKey.Enabled() {
+		return nil
+	}
+	if err := validateKeyType(appKR, cfg.AppKey.KeyName, cfg.AppKey.KeyType); err != nil {
+		return fmt.Errorf("app key type: %w", err)
+	}
+	return nil
+}
+
+// AppPubkey returns the public key bytes of name in kr, as registered in the
+// action app_pubkey field.
+func AppPubkey(kr keyring.Keyring, name string) ([]byte, error) {
+	rec, err := kr.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrKeyNotFound, name, err)
+	}
+	pub, err := rec.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("get pubkey for %q: %w", name, err)
+	}
+	return pub.Bytes(), nil
+}
+
+// appKeyring serves the app key from its own keyring and every other key from
+// the embedded controller keyring, so the cascade SDK can sign metadata with
+// the app key and supernode requests with the Lumera key.
+type appKeyring struct {
+	keyring.Keyring
+	app     keyring.Keyring
+	name    string
+	address sdk.AccAddress
+}
+
+// newAppKeyring overlays key name from app onto base.
+func newAppKeyring(base, app keyring.Keyring, name string) (keyring.Keyring, error) {
+	rec, err := app.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrKeyNotFound, name, err)
+	}
+	addr, err := rec.GetAddress()
+	if err != nil {
+		return nil, fmt.Errorf("get address for %q: %w", name, err)
+	}
+	return &appKeyring{Keyring: base, app: app, name: name, address: addr}, nil
+}
+
+func (k *appKeyring) Key(uid string) (*keyring.Record, error) {
+	if uid == k.name {
+		return k.app.Key(uid)
+	}
+	return k.Keyring.Key(uid)
+}
+
+func (k *appKeyring) KeyByAddress(address sdk.Address) (*keyring.Record, error) {
+	if bytes.Equal(address.Bytes(), k.address) {
+		return k.app.KeyByAddress(address)
+	}
+	return k.Keyring.KeyByAddress(address)
+}
+
+func (k *appKeyring) Sign(uid string, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	if uid == k.name {
+		return k.app.Sign(uid, msg, signMode)
+	}
+	return k.Keyring.Sign(uid, msg, signMode)
+}
+
+func (k *appKeyring) SignByAddress(address sdk.Address, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	if bytes.Equal(address.Bytes(), k.address) {
+		return k.app.SignByAddress(address, msg, signMode)
+	}
+	return k.Keyring.SignByAddress(address, msg, signMode)
+}
@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dkeyring "github.com/99designs/keyring"
+	sdkcrypto "github.com/LumeraProtocol/sdk-go/pkg/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+)
+
+const (
+	// keyringUnlockAttempts bounds interactive passphrase entry.
+	keyringUnlockAttempts = 3
+	// minPassphraseLength matches the cosmos keyring rule for new keyrings.
+	minPassphraseLength = 8
+	// keyringFileDir and keyhashFile follow the cosmos keyring layout, so file
+	// keyrings stay usable by the chain binaries.
+	keyringFileDir = "keyring-file"
+	keyhashFile    = "keyhash"
+)
+
+// KeyringUnlockError reports a keyring that could not be unlocked. Err is
+// ErrWrongPassphrase or ErrPassphraseRequired; Source is the passphrase
+// source that was tried and Attempts how many passphrases were rejected.
+type KeyringUnlockError struct {
+	Dir      string
+	Source   string
+	Attempts int
+	Err      error
+}
+
+func (e *KeyringUnlockError) Error() string {
+	if errors.Is(e.Err, ErrPassphraseRequired) {
+		return fmt.Sprintf("unlock keyring %s: %v and stdin is not a terminal; set a keyring_passphrase_* source or use --passphrase-stdin", e.Dir, e.Err)
+	}
+	if e.Attempts > 1 {
+		return fmt.Sprintf("unlock keyring %s: %v from %s after %d attempts", e.Dir, e.Err, e.Source, e.Attempts)
+	}
+	return fmt.Sprintf("unlock keyring %s: %v from %s", e.Dir, e.Err, e.Source)
+}
+
+func (e *KeyringUnlockError) Unwrap() error { return e.Err }
+
+// openKeyring opens a local keyring. The file backend (and the os backend
+// when it falls back to files) gets its passphrase from a keyringUnlocker
+// instead of the cosmos prompt, which reads the terminal even when a source is
+// configured and retries a piped passphrase without telling why it failed.
+func openKeyring(appName, backend, dir string, src passphraseSource) (keyring.Keyring, error) {
+	if backend == "test" {
+		kr, err := sdkcrypto.NewKeyring(sdkcrypto.KeyringParams{AppName: appName, Backend: backend, Dir: dir})
+		if err != nil {
+			return nil, fmt.Errorf("init keyring: %w", err)
+		}
+		return kr, nil
+	}
+	cfg := dkeyring.Config{ServiceName: appName}
+	switch backend {
+	case "file":
+		cfg.AllowedBackends = []dkeyring.BackendType{dkeyring.FileBackend}
+		cfg.FileDir = filepath.Join(dir, keyringFileDir)
+	case "os":
+		if dir == "" {
+			// Same default as sdk-go.
+			home, _ := os.UserHomeDir()
+			dir = filepath.Join(home, ".lumera")
+		}
+		cfg.FileDir = dir
+		cfg.KeychainTrustApplication = true
+	default:
+		return nil, fmt.Errorf("init keyring: unknown backend %q", backend)
+	}
+	unlocker := newKeyringUnlocker(cfg.FileDir, src)
+	cfg.FilePasswordFunc = unlocker.passphrase
+	db, err := dkeyring.Open(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init keyring: %w", err)
+	}
+	if backend == "file" {
+		if err := unlockFileKeyring(db); err != nil {
+			return nil, err
+		}
+	}
+	return keyring.NewInMemoryWithKeyring(db, keyringCodec(), keyringAlgos), nil
+}
+
+// keyringAlgos enables both supported key types, as sdk-go's NewKeyring does.
+func keyringAlgos(o *keyring.Options) {
+	algos := keyring.SigningAlgoList{sdkcrypto.KeyTypeEVM.SigningAlgo(), hd.Secp256k1}
+	o.SupportedAlgos = algos
+	o.SupportedAlgosLedger = algos
+}
+
+// unlockFileKeyring decrypts one stored key so a wrong or missing passphrase
+// fails here; listing through the cosmos keyring would skip undecryptable
+// keys silently. An empty keyring is unlocked when its first key is written.
+func unlockFileKeyring(db dkeyring.Keyring) error {
+	names, err := db.Keys()
+	if err != nil {
+		return fmt.Errorf("unlock keyring: %w", err)
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".info") {
+			continue
+		}
+		if _, err := db.Get(name); err != nil {
+			var unlockErr *KeyringUnlockError
+			if errors.As(err, &unlockErr) {
+				return err
+			}
+			return fmt.Errorf("unlock keyring: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// keyringUnlocker supplies the passphrase of a file keyring. A configured
+// source gets a single attempt; without one it prompts on the terminal up to
+// keyringUnlockAttempts times, and fails fast when stdin is not a terminal.
+// Passphrases are checked against the keyring's keyhash (written when the
+// keyring is created, as the cosmos keyring does), so a wrong one is reported
+// as ErrWrongPassphrase. Our copy of the passphrase is zeroed once handed over;
+// the keyring library keeps its own for the life of the process.
+type keyringUnlocker struct {
+	dir    string
+	source passphraseSource
+	// prompt reads a passphrase from the terminal; nil when stdin is not one.
+	prompt func(prompt string) ([]byte, error)
+	stderr io.Writer
+}
+
+func newKeyringUnlocker(dir string, source passphraseSource) *keyringUnlocker {
+	u := &keyringUnlocker{dir: dir, source: source, stderr: os.Stderr}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		u.prompt = terminalPrompt
+	}
+	return u
+}
+
+func terminalPrompt(prompt string) ([]byte, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+	return term.ReadPassword(int(os.Stdin.Fd()))
+}
+
+// passphrase is the keyring's FilePasswordFunc; it runs once per keyring.
+func (u *keyringUnlocker) passphrase(string) (string, error) {
+	keyhash, err := os.ReadFile(filepath.Join(u.dir, keyhashFile))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("read keyring keyhash: %w", err)
+	}
+	created := keyhash == nil && !u.hasKeys()
+	pass, err := u.source.read()
+	if err != nil {
+		return "", err
+	}
+	if pass != nil {
+		defer clear(pass)
+		source := u.source.set()[0]
+		if created && len(pass) < minPassphraseLength {
+			return "", configError(source, fmt.Sprintf("must be at least %d characters for a new keyring", minPassphraseLength))
+		}
+		if err := u.check(keyhash, pass); err != nil {
+			if errors.Is(err, ErrWrongPassphrase) {
+				return "", &KeyringUnlockError{Dir: u.dir, Source: source, Attempts: 1, Err: err}
+			}
+			return "", err
+		}
+		return string(pass), nil
+	}
+	if u.prompt == nil {
+		return "", &KeyringUnlockError{Dir: u.dir, Err: ErrPassphraseRequired}
+	}
+	for attempt := 1; attempt <= keyringUnlockAttempts; attempt++ {
+		pass, err := u.prompt(fmt.Sprintf("Enter keyring passphrase (attempt %d/%d): ", attempt, keyringUnlockAttempts))
+		if err != nil {
+			return "", fmt.Errorf("read keyring passphrase: %w", err)
+		}
+		if created {
+			if ok, err := u.confirm(pass); err != nil || !ok {
+				clear(pass)
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+		}
+		if err := u.check(keyhash, pass); err != nil {
+			clear(pass)
+			if errors.Is(err, ErrWrongPassphrase) {
+				fmt.Fprintln(u.stderr, "incorrect passphrase")
+				continue
+			}
+			return "", err
+		}
+		s := string(pass)
+		clear(pass)
+		return s, nil
+	}
+	return "", &KeyringUnlockError{Dir: u.dir, Source: "terminal prompt", Attempts: keyringUnlockAttempts, Err: ErrWrongPassphrase}
+}
+
+// confirm enforces the new-keyring length rule and asks for pass again.
+func (u *keyringUnlocker) confirm(pass []byte) (bool, error) {
+	if len(pass) < minPassphraseLength {
+		fmt.Fprintf(u.stderr, "passphrase must be at least %d characters\n", minPassphraseLength)
+		return false, nil
+	}
+	again, err := u.prompt("Re-enter keyring passphrase: ")
+	if err != nil {
+		return false, fmt.Errorf("read keyring passphrase: %w", err)
+	}
+	defer clear(again)
+	if !bytes.Equal(pass, again) {
+		fmt.Fprintln(u.stderr, "passphrases do not match")
+		return false, nil
+	}
+	return true, nil
+}
+
+// check verifies pass against keyhash. Without a keyhash it records one for a
+// new keyring; older keyrings with keys but no keyhash are left to fail on
+// decryption.
+func (u *keyringUnlocker) check(keyhash, pass []byte) error {
+	if keyhash != nil {
+		if bcrypt.CompareHashAndPassword(keyhash, pass) != nil {
+			return ErrWrongPassphrase
+		}
+		return nil
+	}
+	if u.hasKeys() {
+		return nil
+	}
+	hash, err := bcrypt.GenerateFromPassword(pass, bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hash keyring passphrase: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(u.dir, keyhashFile), hash, 0o600); err != nil {
+		return fmt.Errorf("write keyring keyhash: %w", err)
+	}
+	return nil
+}
+
+// hasKeys reports whether the keyring dir holds any key files.
+func (u *keyringUnlocker) hasKeys() bool {
+	entries, _ := os.ReadDir(u.dir)
+	for _, entry := range entries {
+		if entry.Name() != keyhashFile {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testKeyringApp = "lumera-ica-test"
+
+// openTestKeyring opens the file keyring in dir with a plain-text passphrase.
+func openTestKeyring(t *testing.T, dir, pass string) error {
+	t.Helper()
+	kr, err := openKeyring(testKeyringApp, "file", dir, passphraseSource{section: "controller", plain: pass})
+	if err != nil {
+		return err
+	}
+	if _, err := kr.List(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func TestFileKeyringCreateAndUnlock(t *testing.T) {
+	dir := t.TempDir()
+	kr, err := openKeyring(testKeyringApp, "file", dir, passphraseSource{section: "controller", plain: "s3cretpass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateKey(kr, "alice", "cosmos"); err != nil {
+		t.Fatal(err)
+	}
+	keyhash, err := os.ReadFile(filepath.Join(dir, keyringFileDir, keyhashFile))
+	if err != nil {
+		t.Fatalf("keyhash not written: %v", err)
+	}
+	if bcrypt.CompareHashAndPassword(keyhash, []byte("s3cretpass")) != nil {
+		t.Fatal("keyhash does not match the passphrase")
+	}
+
+	if err := openTestKeyring(t, dir, "s3cretpass"); err != nil {
+		t.Fatalf("unlock with the right passphrase: %v", err)
+	}
+
+	err = openTestKeyring(t, dir, "wrongpass")
+	var unlockErr *KeyringUnlockError
+	if !errors.As(err, &unlockErr) || !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("err = %v, want a wrong passphrase KeyringUnlockError", err)
+	}
+	if unlockErr.Attempts != 1 || unlockErr.Source != "controller.keyring_passphrase_plain" {
+		t.Fatalf("unlock error = %+v, want 1 attempt from controller.keyring_passphrase_plain", unlockErr)
+	}
+}
+
+func TestFileKeyringShortPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	kr, err := openKeyring(testKeyringApp, "file", dir, passphraseSource{section: "controller", plain: "short"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CreateKey(kr, "alice", "cosmos")
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "controller.keyring_passphrase_plain" {
+		t.Fatalf("err = %v, want a ConfigError for controller.keyring_passphrase_plain", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyringFileDir, keyhashFile)); !os.IsNotExist(err) {
+		t.Fatalf("keyhash written for a rejected passphrase: %v", err)
+	}
+}
+
+// scriptedPrompt answers terminal prompts from answers, in order.
+type scriptedPrompt struct {
+	answers []string
+	asked   int
+}
+
+func (p *scriptedPrompt) prompt(string) ([]byte, error) {
+	if p.asked >= len(p.answers) {
+		return nil, errors.New("no more answers")
+	}
+	p.asked++
+	return []byte(p.answers[p.asked-1]), nil
+}
+
+// newPromptUnlocker returns an unlocker for dir that reads answers as if typed
+// on a terminal.
+func newPromptUnlocker(dir string, answers ...string) (*keyringUnlocker, *scriptedPrompt, *bytes.Buffer) {
+	script := &scriptedPrompt{answers: answers}
+	var stderr bytes.Buffer
+	return &keyringUnlocker{dir: dir, prompt: script.prompt, stderr: &stderr}, script, &stderr
+}
+
+// writeKeyhash records pass as the passphrase of the keyring in dir.
+func writeKeyhash(t *testing.T, dir, pass string) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, keyhashFile), hash, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeyringUnlockerPromptCreate(t *testing.T) {
+	dir := t.TempDir()
+	// Too short, then a mismatched confirmation, then a good passphrase.
+	u, script, stderr := newPromptUnlocker(dir, "short", "longenough", "different", "longenough", "longenough")
+	pass, err := u.passphrase("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pass != "longenough" || script.asked != 5 {
+		t.Fatalf("pass = %q after %d prompts, want longenough after 5", pass, script.asked)
+	}
+	for _, msg := range []string{"at least 8 characters", "do not match"} {
+		if !strings.Contains(stderr.String(), msg) {
+			t.Errorf("stderr %q does not mention %q", stderr.String(), msg)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyhashFile)); err != nil {
+		t.Fatalf("keyhash not written: %v", err)
+	}
+}
+
+func TestKeyringUnlockerPromptRetry(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyhash(t, dir, "s3cretpass")
+	u, script, _ := newPromptUnlocker(dir, "wrongpass", "s3cretpass")
+	pass, err := u.passphrase("")
+	if err != nil || pass != "s3cretpass" || script.asked != 2 {
+		t.Fatalf("pass = %q, err = %v after %d prompts; want s3cretpass on the second", pass, err, script.asked)
+	}
+}
+
+func TestKeyringUnlockerWrongPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyhash(t, dir, "s3cretpass")
+	u, script, stderr := newPromptUnlocker(dir, "wrong1", "wrong2", "wrong3", "s3cretpass")
+	_, err := u.passphrase("")
+	var unlockErr *KeyringUnlockError
+	if !errors.As(err, &unlockErr) || !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("err = %v, want a wrong passphrase KeyringUnlockError", err)
+	}
+	if unlockErr.Attempts != keyringUnlockAttempts || script.asked != keyringUnlockAttempts {
+		t.Fatalf("attempts = %d after %d prompts, want %d", unlockErr.Attempts, script.asked, keyringUnlockAttempts)
+	}
+	if n := strings.Count(stderr.String(), "incorrect passphrase"); n != keyringUnlockAttempts {
+		t.Fatalf("stderr reports %d incorrect passphrases, want %d", n, keyringUnlockAttempts)
+	}
+}
+
+func TestKeyringUnlockerNoTerminal(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyhash(t, dir, "s3cretpass")
+	u := &keyringUnlocker{dir: dir, stderr: &bytes.Buffer{}}
+	_, err := u.passphrase("")
+	var unlockErr *KeyringUnlockError
+	if !errors.As(err, &unlockErr) || !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("err = %v, want a passphrase required KeyringUnlockError", err)
+	}
+	if !strings.Contains(err.Error(), "not a terminal") {
+		t.Fatalf("err = %v, want it to say stdin is not a terminal", err)
+	}
+}
+
+func TestPassphraseSources(t *testing.T) {
+	dir := t.TempDir()
+	passFile := filepath.Join(dir, "pass")
+	if err := os.WriteFile(passFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LUMERA_ICA_TEST_PASSPHRASE", "from-env")
+	cases := []struct {
+		name string
+		src  passphraseSource
+		want string
+	}{
+		{"none", passphraseSource{}, ""},
+		{"plain", passphraseSource{plain: "  from-plain "}, "from-plain"},
+		{"file", passphraseSource{file: passFile}, "from-file"},
+		{"env", passphraseSource{env: "LUMERA_ICA_TEST_PASSPHRASE"}, "from-env"},
+		{"command", passphraseSource{command: "echo from-command"}, "from-command"},
+		{"stdin", passphraseSource{stdin: []byte("from-stdin")}, "from-stdin"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.src.section = "controller"
+			if err := tc.src.validate(); err != nil {
+				t.Fatal(err)
+			}
+			pass, err := tc.src.read()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(pass) != tc.want {
+				t.Fatalf("passphrase = %q, want %q", pass, tc.want)
+			}
+		})
+	}
+}
+
+func TestPassphraseSourceErrors(t *testing.T) {
+	dir := t.TempDir()
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LUMERA_ICA_TEST_EMPTY", "")
+	cases := []struct {
+		name  string
+		src   passphraseSource
+		field string
+	}{
+		{"missing file", passphraseSource{file: filepath.Join(dir, "missing")}, "controller.keyring_passphrase_file"},
+		{"empty file", passphraseSource{file: emptyFile}, "controller.keyring_passphrase_file"},
+		{"empty env", passphraseSource{env: "LUMERA_ICA_TEST_EMPTY"}, "controller.keyring_passphrase_env"},
+		{"failing command", passphraseSource{command: "false"}, "controller.keyring_passphrase_command"},
+		{"silent command", passphraseSource{command: "true"}, "controller.keyring_passphrase_command"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.src.section = "controller"
+			_, err := tc.src.read()
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tc.field {
+				t.Fatalf("err = %v, want a ConfigError for %s", err, tc.field)
+			}
+		})
+	}
+
+	both := passphraseSource{section: "controller", plain: "a", stdin: []byte("b")}
+	var cfgErr *ConfigError
+	if err := both.validate(); !errors.As(err, &cfgErr) || !strings.Contains(err.Error(), "--passphrase-stdin") {
+		t.Fatalf("err = %v, want a ConfigError naming --passphrase-stdin", err)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const passphraseCommandTimeout = 30 * time.Second
+
+// passphraseSource is the set of passphrase settings for one keyring. At most
+// one of them may be set; with none the keyring prompts on the terminal.
+type passphraseSource struct {
+	section string
+	plain   string
+	file    string
+	env     string
+	command string
+	stdin   []byte
+}
+
+func (c ControllerConfig) passphraseSource() passphraseSource {
+	return passphraseSource{
+		section: "controller",
+		plain:   c.KeyringPassphrasePlain,
+		file:    c.KeyringPassphraseFile,
+		env:     c.KeyringPassphraseEnv,
+		command: c.KeyringPassphraseCommand,
+		stdin:   c.stdinPassphrase,
+	}
+}
+
+func (a AppKeyConfig) passphraseSource() passphraseSource {
+	return passphraseSource{
+		section: "app_key",
+		plain:   a.KeyringPassphrasePlain,
+		file:    a.KeyringPassphraseFile,
+		env:     a.KeyringPassphraseEnv,
+		command: a.KeyringPassphraseCommand,
+	}
+}
+
+// set lists the configured sources by config field.
+func (s passphraseSource) set() []string {
+	var fields []string
+	for _, src := range []struct{ field, value string }{
+		{"keyring_passphrase_plain", s.plain},
+		{"keyring_passphrase_file", s.file},
+		{"keyring_passphrase_env", s.env},
+		{"keyring_passphrase_command", s.command},
+	} {
+		if strings.TrimSpace(src.value) != "" {
+			fields = append(fields, s.section+"."+src.field)
+		}
+	}
+	if s.stdin != nil {
+		fields = append(fields, "--passphrase-stdin")
+	}
+	return fields
+}
+
+// validate rejects more than one passphrase source. Sources are only read when
+// the keyring is opened.
+func (s passphraseSource) validate() error {
+	fields := s.set()
+	if len(fields) > 1 {
+		return configError(fields[0], "cannot be combined with "+strings.Join(fields[1:], ", "))
+	}
+	return nil
+}
+
+// read returns the passphrase from the configured source, or nil when none is
+// set. The caller owns the returned slice and should zero it once used.
+func (s passphraseSource) read() ([]byte, error) {
+	field := func(name string) string { return s.section + "." + name }
+	switch {
+	case s.stdin != nil:
+		return bytes.Clone(s.stdin), nil
+	case strings.TrimSpace(s.plain) != "":
+		return []byte(strings.TrimSpace(s.plain)), nil
+	case strings.TrimSpace(s.file) != "":
+		data, err := os.ReadFile(strings.TrimSpace(s.file))
+		if err != nil {
+			return nil, wrapConfigError(field("keyring_passphrase_file"), err)
+		}
+		pass := bytes.Clone(bytes.TrimSpace(data))
+		clear(data)
+		if len(pass) == 0 {
+			return nil, configError(field("keyring_passphrase_file"), "is empty")
+		}
+		return pass, nil
+	case strings.TrimSpace(s.env) != "":
+		name := strings.TrimSpace(s.env)
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return nil, configError(field("keyring_passphrase_env"), fmt.Sprintf("names env var %s, which is unset or empty", name))
+		}
+		return []byte(value), nil
+	case strings.TrimSpace(s.command) != "":
+		pass, err := runPassphraseCommand(s.command)
+		if err != nil {
+			return nil, wrapConfigError(field("keyring_passphrase_command"), err)
+		}
+		return pass, nil
+	}
+	return nil, nil
+}
+
+// runPassphraseCommand runs command (split on whitespace, no shell) and returns
+// its stdout without the trailing newline.
+func runPassphraseCommand(command string) ([]byte, error) {
+	args := strings.Fields(command)
+	ctx, cancel := context.WithTimeout(context.Background(), passphraseCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	out := stdout.Bytes()
+	defer clear(out)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("run %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("run %s: %w", args[0], err)
+	}
+	pass := bytes.Clone(bytes.TrimRight(out, "\r\n"))
+	if len(pass) == 0 {
+		return nil, fmt.Errorf("%s printed no passphrase", args[0])
+	}
+	return pass, nil
+}
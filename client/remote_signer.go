@@ -0,0 +1,279 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	sdkethsecp256k1 "github.com/LumeraProtocol/sdk-go/pkg/crypto/ethsecp256k1"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// The remote signing protocol is JSON over HTTP(S):
+//
+//	GET  /v1/keys -> {"keys":[{"name":"...","type":"secp256k1","pub_key":"<base64>"}]}
+//	POST /v1/sign {"key_name":"...","sign_mode":"SIGN_MODE_DIRECT","message":"<base64>"}
+//	     -> {"signature":"<base64>"}
+//
+// Failures use a non-2xx status with {"error":"..."}; 404 means an unknown key.
+const (
+	signerKeysPath       = "/v1/keys"
+	signerSignPath       = "/v1/sign"
+	defaultSignerTimeout = 30 * time.Second
+	maxSignerBodySize    = 1 << 20
+)
+
+type signerKeyJSON struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	PubKey []byte `json:"pub_key"`
+}
+
+type signerKeysResponse struct {
+	Keys []signerKeyJSON `json:"keys"`
+}
+
+type signRequest struct {
+	KeyName  string `json:"key_name"`
+	SignMode string `json:"sign_mode"`
+	Message  []byte `json:"message"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type signerErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSignerError reports a failed call to the remote signer.
+// StatusCode is zero when no HTTP response was received.
+type RemoteSignerError struct {
+	Endpoint   string
+	Op         string
+	StatusCode int
+	Err        error
+}
+
+func (e *RemoteSignerError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("remote signer %s: %s: HTTP %d: %v", e.Endpoint, e.Op, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("remote signer %s: %s: %v", e.Endpoint, e.Op, e.Err)
+}
+
+func (e *RemoteSignerError) Unwrap() error { return e.Err }
+
+func (e *RemoteSignerError) Is(target error) bool { return target == ErrRemoteSigner }
+
+// RemoteSigner is a Signer that calls a remote signing service, so private keys
+// never reach the host running the client.
+type RemoteSigner struct {
+	endpoint string
+	http     *http.Client
+}
+
+// NewRemoteSigner builds a RemoteSigner from the controller.signer_* settings.
+// https endpoints use the system roots unless signer_tls_ca_file is set, and
+// present signer_tls_cert_file/signer_tls_key_file as a client certificate.
+func NewRemoteSigner(cfg ControllerConfig) (*RemoteSigner, error) {
+	endpoint := strings.TrimRight(strings.TrimSpace(cfg.SignerEndpoint), "/")
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, wrapConfigError("controller.signer_endpoint", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if u.Scheme == "https" {
+		tlsCfg, err := remoteSignerTLS(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &RemoteSigner{
+		endpoint: endpoint,
+		http:     &http.Client{Transport: transport, Timeout: defaultSignerTimeout},
+	}, nil
+}
+
+// remoteSignerTLS loads the CA bundle and client certificate for the remote signer.
+func remoteSignerTLS(cfg ControllerConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: strings.TrimSpace(cfg.SignerTLSServerName)}
+	if caFile := strings.TrimSpace(cfg.SignerTLSCAFile); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, wrapConfigError("controller.signer_tls_ca_file", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, configError("controller.signer_tls_ca_file", "contains no PEM certificates")
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if certFile := strings.TrimSpace(cfg.SignerTLSCertFile); certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, strings.TrimSpace(cfg.SignerTLSKeyFile))
+		if err != nil {
+			return nil, wrapConfigError("controller.signer_tls_cert_file", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// Endpoint returns the remote signer base URL.
+func (s *RemoteSigner) Endpoint() string {
+	return s.endpoint
+}
+
+// Keys lists the keys served by the remote signer.
+func (s *RemoteSigner) Keys(ctx context.Context) ([]SignerKey, error) {
+	var resp signerKeysResponse
+	if err := s.call(ctx, "list keys", http.MethodGet, signerKeysPath, nil, &resp); err != nil {
+		return nil, err
+	}
+	keys := make([]SignerKey, 0, len(resp.Keys))
+	for _, k := range resp.Keys {
+		pub, err := decodeSignerPubKey(k.Type, k.PubKey)
+		if err != nil {
+			return nil, &RemoteSignerError{Endpoint: s.endpoint, Op: "list keys", Err: fmt.Errorf("key %q: %w", k.Name, err)}
+		}
+		keys = append(keys, SignerKey{Name: k.Name, PubKey: pub})
+	}
+	return keys, nil
+}
+
+// Sign asks the remote signer to sign msg with the named key.
+func (s *RemoteSigner) Sign(ctx context.Context, keyName string, msg []byte, signMode signing.SignMode) ([]byte, error) {
+	req := signRequest{KeyName: keyName, SignMode: signMode.String(), Message: msg}
+	var resp signResponse
+	if err := s.call(ctx, "sign with "+keyName, http.MethodPost, signerSignPath, req, &resp); err != nil {
+		var remoteErr *RemoteSignerError
+		if errors.As(err, &remoteErr) && remoteErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %q: %w", ErrKeyNotFound, keyName, err)
+		}
+		return nil, err
+	}
+	if len(resp.Signature) == 0 {
+		return nil, &RemoteSignerError{Endpoint: s.endpoint, Op: "sign with " + keyName, Err: fmt.Errorf("empty signature")}
+	}
+	return resp.Signature, nil
+}
+
+// call performs one JSON request against the remote signer.
+func (s *RemoteSigner) call(ctx context.Context, op, method, path string, in, out any) error {
+	fail := func(status int, err error) error {
+		return &RemoteSignerError{Endpoint: s.endpoint, Op: op, StatusCode: status, Err: err}
+	}
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fail(0, err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, body)
+	if err != nil {
+		return fail(0, err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fail(0, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSignerBodySize))
+	if err != nil {
+		return fail(resp.StatusCode, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		var errResp signerErrorResponse
+		if json.Unmarshal(data, &errResp) != nil || errResp.Error == "" {
+			errResp.Error = strings.TrimSpace(string(data))
+		}
+		return fail(resp.StatusCode, errors.New(errResp.Error))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fail(resp.StatusCode, fmt.Errorf("decode response: %w", err))
+	}
+	return nil
+}
+
+// NewSignerHandler serves signer over the remote signing protocol. It lets a
+// local keyring stand in for a remote signer (see `signer serve`).
+func NewSignerHandler(signer Signer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+signerKeysPath, func(w http.ResponseWriter, r *http.Request) {
+		keys, err := signer.Keys(r.Context())
+		if err != nil {
+			writeSignerJSON(w, http.StatusInternalServerError, signerErrorResponse{Error: err.Error()})
+			return
+		}
+		resp := signerKeysResponse{Keys: make([]signerKeyJSON, 0, len(keys))}
+		for _, k := range keys {
+			resp.Keys = append(resp.Keys, signerKeyJSON{Name: k.Name, Type: k.PubKey.Type(), PubKey: k.PubKey.Bytes()})
+		}
+		writeSignerJSON(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("POST "+signerSignPath, func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxSignerBodySize)).Decode(&req); err != nil {
+			writeSignerJSON(w, http.StatusBadRequest, signerErrorResponse{Error: "decode request: " + err.Error()})
+			return
+		}
+		mode, ok := signing.SignMode_value[req.SignMode]
+		if !ok {
+			writeSignerJSON(w, http.StatusBadRequest, signerErrorResponse{Error: fmt.Sprintf("unknown sign_mode %q", req.SignMode)})
+			return
+		}
+		sig, err := signer.Sign(r.Context(), req.KeyName, req.Message, signing.SignMode(mode))
+		switch {
+		case errors.Is(err, ErrKeyNotFound):
+			writeSignerJSON(w, http.StatusNotFound, signerErrorResponse{Error: err.Error()})
+		case err != nil:
+			writeSignerJSON(w, http.StatusInternalServerError, signerErrorResponse{Error: err.Error()})
+		default:
+			writeSignerJSON(w, http.StatusOK, signResponse{Signature: sig})
+		}
+	})
+	return mux
+}
+
+func writeSignerJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// decodeSignerPubKey rebuilds a public key from its type name and bytes.
+func decodeSignerPubKey(keyType string, key []byte) (cryptotypes.PubKey, error) {
+	switch keyType {
+	case "secp256k1":
+		if len(key) != secp256k1.PubKeySize {
+			return nil, fmt.Errorf("secp256k1 pub_key must be %d bytes (got %d)", secp256k1.PubKeySize, len(key))
+		}
+		return &secp256k1.PubKey{Key: key}, nil
+	case sdkethsecp256k1.KeyType:
+		if len(key) != sdkethsecp256k1.PubKeySize {
+			return nil, fmt.Errorf("%s pub_key must be %d bytes (got %d)", keyType, sdkethsecp256k1.PubKeySize, len(key))
+		}
+		return &sdkethsecp256k1.PubKey{Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
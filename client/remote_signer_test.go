@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// newTestSigner serves a KeyringSigner over an in-memory keyring holding one
+// cosmos and one evm key, and returns a RemoteSigner pointed at it.
+func newTestSigner(t *testing.T, useTLS bool) *RemoteSigner {
+	t.Helper()
+	kr := keyring.NewInMemory(keyringCodec(), keyringAlgos)
+	for name, keyType := range map[string]string{"alice": "cosmos", "bob": "evm"} {
+		if _, err := CreateKey(kr, name, keyType); err != nil {
+			t.Fatalf("create key %s: %v", name, err)
+		}
+	}
+	return newRemoteSignerFor(t, NewSignerHandler(KeyringSigner{Keyring: kr}), useTLS)
+}
+
+// newRemoteSignerFor starts handler under httptest and returns a RemoteSigner
+// for it. TLS servers are trusted through signer_tls_ca_file.
+func newRemoteSignerFor(t *testing.T, handler http.Handler, useTLS bool) *RemoteSigner {
+	t.Helper()
+	var srv *httptest.Server
+	cfg := ControllerConfig{}
+	if useTLS {
+		srv = httptest.NewTLSServer(handler)
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		if err := os.WriteFile(caFile, ca, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg.SignerTLSCAFile = caFile
+	} else {
+		srv = httptest.NewServer(handler)
+	}
+	t.Cleanup(srv.Close)
+	cfg.SignerEndpoint = srv.URL
+	signer, err := NewRemoteSigner(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestRemoteSigner(t *testing.T) {
+	for _, useTLS := range []bool{false, true} {
+		name := "http"
+		if useTLS {
+			name = "https"
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			signer := newTestSigner(t, useTLS)
+
+			keys, err := signer.Keys(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != 2 || keys[0].Name != "alice" || keys[1].Name != "bob" {
+				t.Fatalf("keys = %+v, want alice and bob", keys)
+			}
+			msg := []byte("sign bytes")
+			for _, key := range keys {
+				sig, err := signer.Sign(ctx, key.Name, msg, signing.SignMode_SIGN_MODE_DIRECT)
+				if err != nil {
+					t.Fatalf("sign with %s: %v", key.Name, err)
+				}
+				if !key.PubKey.VerifySignature(msg, sig) {
+					t.Fatalf("signature from %s (%s) does not verify", key.Name, key.PubKey.Type())
+				}
+			}
+
+			_, err = signer.Sign(ctx, "carol", msg, signing.SignMode_SIGN_MODE_DIRECT)
+			if !errors.Is(err, ErrKeyNotFound) {
+				t.Fatalf("sign with unknown key: err = %v, want ErrKeyNotFound", err)
+			}
+			var remoteErr *RemoteSignerError
+			if !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusNotFound {
+				t.Fatalf("sign with unknown key: err = %v, want a 404 RemoteSignerError", err)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerKeyring(t *testing.T) {
+	kr, err := NewSignerKeyring(context.Background(), newTestSigner(t, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("sign bytes")
+	sig, pub, err := kr.Sign("bob", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.VerifySignature(msg, sig) {
+		t.Fatal("signature does not verify")
+	}
+}
+
+func TestRemoteSignerErrors(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantMsg string
+	}{
+		{"json error", http.StatusInternalServerError, `{"error":"hsm offline"}`, "hsm offline"},
+		{"plain error", http.StatusBadGateway, "upstream down", "upstream down"},
+		{"forbidden", http.StatusForbidden, `{"error":"client certificate required"}`, "client certificate required"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := newRemoteSignerFor(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}), false)
+			for op, call := range map[string]func() error{
+				"keys": func() error { _, err := signer.Keys(ctx); return err },
+				"sign": func() error {
+					_, err := signer.Sign(ctx, "alice", []byte("x"), signing.SignMode_SIGN_MODE_DIRECT)
+					return err
+				},
+			} {
+				err := call()
+				var remoteErr *RemoteSignerError
+				if !errors.As(err, &remoteErr) {
+					t.Fatalf("%s: err = %v, want *RemoteSignerError", op, err)
+				}
+				if remoteErr.StatusCode != tc.status || !strings.Contains(err.Error(), tc.wantMsg) {
+					t.Fatalf("%s: err = %v, want HTTP %d with %q", op, err, tc.status, tc.wantMsg)
+				}
+				if !errors.Is(err, ErrRemoteSigner) || errors.Is(err, ErrKeyNotFound) {
+					t.Fatalf("%s: err = %v, want ErrRemoteSigner only", op, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoteSignerEmptySignature(t *testing.T) {
+	signer := newRemoteSignerFor(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSignerJSON(w, http.StatusOK, signResponse{})
+	}), false)
+	_, err := signer.Sign(context.Background(), "alice", []byte("x"), signing.SignMode_SIGN_MODE_DIRECT)
+	var remoteErr *RemoteSignerError
+	if !errors.As(err, &remoteErr) || !strings.Contains(err.Error(), "empty signature") {
+		t.Fatalf("err = %v, want an empty signature RemoteSignerError", err)
+	}
+}
+
+func TestRemoteSignerUnknownKeyType(t *testing.T) {
+	signer := newRemoteSignerFor(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSignerJSON(w, http.StatusOK, signerKeysResponse{Keys: []signerKeyJSON{{Name: "ed", Type: "ed25519", PubKey: make([]byte, 32)}}})
+	}), false)
+	_, err := signer.Keys(context.Background())
+	var remoteErr *RemoteSignerError
+	if !errors.As(err, &remoteErr) || !strings.Contains(err.Error(), `unsupported key type "ed25519"`) {
+		t.Fatalf("err = %v, want an unsupported key type RemoteSignerError", err)
+	}
+}
+
+func TestDecodeSignerPubKey(t *testing.T) {
+	cases := []struct {
+		keyType string
+		size    int
+		wantErr string
+	}{
+		{"secp256k1", 33, ""},
+		{"eth_secp256k1", 33, ""},
+		{"secp256k1", 65, "must be 33 bytes"},
+		{"eth_secp256k1", 20, "must be 33 bytes"},
+		{"ed25519", 32, "unsupported key type"},
+		{"", 33, "unsupported key type"},
+	}
+	for _, tc := range cases {
+		pub, err := decodeSignerPubKey(tc.keyType, make([]byte, tc.size))
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s/%d: unexpected error %v", tc.keyType, tc.size, err)
+		case tc.wantErr == "" && pub.Type() != tc.keyType:
+			t.Errorf("%s/%d: type = %s", tc.keyType, tc.size, pub.Type())
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s/%d: err = %v, want %q", tc.keyType, tc.size, err, tc.wantErr)
+		}
+	}
+}
+
+func TestRemoteSignerConfigWithoutKeyring(t *testing.T) {
+	base := `
+[lumera]
+chain_id = "lumera-testnet-2"
+grpc_endpoint = "localhost:9090"
+rpc_endpoint = "http://localhost:26657"
+key_name = "lumera"
+
+[controller]
+chain_id = "osmo-test-5"
+account_hrp = "osmo"
+grpc_endpoint = "localhost:9091"
+rpc_endpoint = "http://localhost:26658"
+key_name = "osmo-key"
+connection_id = "connection-1"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	remote := base + "signer = \"remote\"\nsigner_endpoint = \"https://signer.internal:7755\"\n"
+	if err := os.WriteFile(path, []byte(remote), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("remote signer config without keyring settings: %v", err)
+	}
+	if !cfg.Controller.RemoteSigner() || cfg.Controller.KeyringBackend != "" {
+		t.Fatalf("signer = %q, keyring_backend = %q", cfg.Controller.Signer, cfg.Controller.KeyringBackend)
+	}
+
+	// The same config with the local keyring signer needs keyring settings.
+	if err := os.WriteFile(path, []byte(base), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadConfig(path)
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "controller.keyring_backend" {
+		t.Fatalf("err = %v, want a ConfigError for controller.keyring_backend", err)
+	}
+}
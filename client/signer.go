@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	sdkethsecp256k1 "github.com/LumeraProtocol/sdk-go/pkg/crypto/ethsecp256k1"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+const (
+	SignerKeyring = "keyring"
+	SignerRemote  = "remote"
+)
+
+// Signer signs with named keys without handing out private keys. It backs both
+// controller tx signing and app-metadata signing. Sign has keyring semantics:
+// msg is the raw sign bytes and hashing is left to the key's algorithm.
+type Signer interface {
+	// Keys lists the keys the signer can sign with.
+	Keys(ctx context.Context) ([]SignerKey, error)
+	// Sign signs msg with the named key.
+	Sign(ctx context.Context, keyName string, msg []byte, signMode signing.SignMode) ([]byte, error)
+}
+
+// SignerKey is a key served by a Signer.
+type SignerKey struct {
+	Name   string
+	PubKey cryptotypes.PubKey
+}
+
+// KeyringSigner signs with keys held in a local cosmos keyring.
+type KeyringSigner struct {
+	Keyring keyring.Keyring
+}
+
+// Keys lists the keyring's keys sorted by name.
+func (s KeyringSigner) Keys(_ context.Context) ([]SignerKey, error) {
+	records, err := s.Keyring.List()
+	if err != nil {
+		return nil, fmt.Errorf("list keyring keys: %w", err)
+	}
+	keys := make([]SignerKey, 0, len(records))
+	for _, rec := range records {
+		pub, err := rec.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("get pubkey for %q: %w", rec.Name, err)
+		}
+		keys = append(keys, SignerKey{Name: rec.Name, PubKey: pub})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// Sign signs msg with the named keyring key.
+func (s KeyringSigner) Sign(_ context.Context, keyName string, msg []byte, signMode signing.SignMode) ([]byte, error) {
+	if _, err := s.Keyring.Key(keyName); err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrKeyNotFound, keyName, err)
+	}
+	sig, _, err := s.Keyring.Sign(keyName, msg, signMode)
+	return sig, err
+}
+
+// keyringCodec registers the standard and eth_secp256k1 key types for keyring records.
+func keyringCodec() codec.Codec {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	sdkethsecp256k1.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// NewSignerKeyring exposes signer as a keyring.Keyring for the sdk-go clients.
+// The signer's public keys are loaded once into an in-memory keyring as offline
+// records, so lookups and address derivation stay local; Sign and SignByAddress
+// are forwarded to the signer.
+func NewSignerKeyring(ctx context.Context, signer Signer) (keyring.Keyring, error) {
+	keys, err := signer.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mem := keyring.NewInMemory(keyringCodec())
+	for _, key := range keys {
+		if _, err := mem.SaveOfflineKey(key.Name, key.PubKey); err != nil {
+			return nil, fmt.Errorf("load signer key %q: %w", key.Name, err)
+		}
+	}
+	return &signerKeyring{Keyring: mem, signer: signer}, nil
+}
+
+// signerKeyring is an in-memory keyring of public keys whose signing is
+// delegated to a Signer.
+type signerKeyring struct {
+	keyring.Keyring
+	signer Signer
+}
+
+func (k *signerKeyring) Sign(uid string, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	rec, err := k.Keyring.Key(uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k.sign(rec, msg, signMode)
+}
+
+func (k *signerKeyring) SignByAddress(address sdk.Address, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	rec, err := k.Keyring.KeyByAddress(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k.sign(rec, msg, signMode)
+}
+
+func (k *signerKeyring) sign(rec *keyring.Record, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	pub, err := rec.GetPubKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get pubkey for %q: %w", rec.Name, err)
+	}
+	// The keyring interface carries no context; the signer applies its own timeout.
+	sig, err := k.signer.Sign(context.Background(), rec.Name, msg, signMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pub.VerifySignature(msg, sig) {
+		return nil, nil, fmt.Errorf("signer returned an invalid signature for %q", rec.Name)
+	}
+	return sig, pub, nil
+}
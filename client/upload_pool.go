@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultUploadConcurrency = 4
+	defaultUploadRetryDelay  = 2 * time.Second
+)
+
+// SupernodeUploader is the subset of the cascade client used by UploadPool.
+type SupernodeUploader interface {
+	UploadToSupernode(ctx context.Context, actionID string, filePath string, signerAddr ...string) (string, error)
+}
+
+// UploadJob identifies one supernode upload for an already registered action.
+// Signer is the ICA address that created the action.
+type UploadJob struct {
+	ActionID string
+	Path     string
+	Signer   string
+}
+
+// UploadJobResult reports the outcome of an UploadJob.
+type UploadJobResult struct {
+	Job      UploadJob
+	TaskID   string
+	Attempts int
+	Duration time.Duration
+	Err      error
+}
+
+// UploadPool runs supernode uploads with bounded concurrency and per-job retries.
+// Retries is the number of extra attempts after the first failure.
+type UploadPool struct {
+	Uploader    SupernodeUploader
+	Concurrency int
+	Retries     int
+	RetryDelay  time.Duration
+}
+
+// Run uploads all jobs and returns their results in job order.
+// onResult, when set, is called once per job as it finishes; calls are serialized.
+// Cancelling ctx stops workers from starting new jobs; unstarted jobs report ctx.Err().
+func (p *UploadPool) Run(ctx context.Context, jobs []UploadJob, onResult func(UploadJobResult)) []UploadJobResult {
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultUploadConcurrency
+	}
+	results := make([]UploadJobResult, len(jobs))
+	indexes := make(chan int)
+	var mu sync.Mutex
+	report := func(i int, res UploadJobResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[i] = res
+		if onResult != nil {
+			onResult(res)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(jobs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				report(i, p.runJob(ctx, jobs[i]))
+			}
+		}()
+	}
+	for i := range jobs {
+		if ctx.Err() != nil {
+			report(i, UploadJobResult{Job: jobs[i], Err: ctx.Err()})
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			report(i, UploadJobResult{Job: jobs[i], Err: ctx.Err()})
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// runJob uploads a single job, retrying failures until the attempt budget or ctx runs out.
+func (p *UploadPool) runJob(ctx context.Context, job UploadJob) UploadJobResult {
+	res := UploadJobResult{Job: job}
+	if p.Uploader == nil {
+		res.Err = fmt.Errorf("supernode uploader is nil")
+		return res
+	}
+	delay := p.RetryDelay
+	if delay <= 0 {
+		delay = defaultUploadRetryDelay
+	}
+	start := time.Now()
+	for {
+		res.Attempts++
+		res.TaskID, res.Err = p.Uploader.UploadToSupernode(ctx, job.ActionID, job.Path, job.Signer)
+		if res.Err == nil || res.Attempts > p.Retries || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			res.Duration = time.Since(start)
+			return res
+		case <-time.After(delay):
+		}
+	}
+	res.Duration = time.Since(start)
+	return res
+}
@@ -19,6 +19,8 @@ func newActionCmd(app *app) *cobra.Command {
 	}
 	cmd.AddCommand(newActionApproveCmd(app))
 	cmd.AddCommand(newActionStatusCmd(app))
+	cmd.AddCommand(newActionListCmd(app))
+	cmd.AddCommand(newActionWatchCmd(app))
 	return cmd
 }
 
@@ -26,6 +28,7 @@ func newActionCmd(app *app) *cobra.Command {
 func newActionApproveCmd(app *app) *cobra.Command {
 	var actionID string
 	var icaAddress string
+	var timeouts icaTimeoutFlags
 	cmd := &cobra.Command{
 		Use:   "approve [action-id]",
 		Short: "Approve an action via ICA",
@@ -41,7 +44,10 @@ func newActionApproveCmd(app *app) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			ctx, cancel := commandContext(cmd)
+			if err := timeouts.apply(cmd, cfg); err != nil {
+				return err
+			}
+			ctx, cancel := icaCommandContext(cmd, cfg)
 			defer cancel()
 
 			// Initialize cascade client + controller helper.
@@ -63,6 +69,11 @@ func newActionApproveCmd(app *app) *cobra.Command {
 					return err
 				}
 			}
+			// Top up the ICA first when the [funding] policy requires it.
+			topUp, err := controller.EnsureICAFunded(ctx, icaAddress)
+			if err != nil {
+				return err
+			}
 			// Build and submit the approve action message through ICA.
 			msg, err := cascade.CreateApproveActionMessage(ctx, actionID, cascade.WithApproveCreator(icaAddress))
 			if err != nil {
@@ -70,19 +81,24 @@ func newActionApproveCmd(app *app) *cobra.Command {
 			}
 			txHash, err := controller.SendApproveAction(ctx, msg)
 			if err != nil {
-				return err
+				return withDetails(err, map[string]any{"action_id": actionID, "ica_address": icaAddress})
 			}
-			return writeJSON(map[string]any{
+			payload := map[string]any{
 				"status":            "ok",
 				"action_id":         actionID,
 				"tx_hash":           txHash,
 				"ica_address":       icaAddress,
 				"ica_owner_address": controller.OwnerAddress(),
-			})
+			}
+			if topUp != nil {
+				payload["top_up"] = fundResultJSON(topUp)
+			}
+			return writeJSON(payload)
 		},
 	}
 	cmd.Flags().StringVar(&actionID, "action-id", "", "Action ID to approve")
 	cmd.Flags().StringVar(&icaAddress, "ica-address", "", "ICA address to approve from")
+	timeouts.register(cmd)
 	return cmd
 }
 
@@ -125,25 +141,32 @@ func newActionStatusCmd(app *app) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			payload := map[string]any{
-				"status":       "ok",
-				"action_id":    action.ID,
-				"state":        action.State,
-				"type":         action.Type,
-				"creator":      action.Creator,
-				"price":        action.Price,
-				"block_height": action.BlockHeight,
-				"expires_at":   action.ExpirationTime.Unix(),
-			}
-			if meta, ok := action.Metadata.(*types.CascadeMetadata); ok && meta != nil {
-				payload["is_public"] = meta.Public
-			}
-			if len(action.AppPubkey) > 0 {
-				payload["app_pubkey"] = base64.StdEncoding.EncodeToString(action.AppPubkey)
-			}
+			payload := actionJSON(action)
+			payload["status"] = "ok"
 			return writeJSON(payload)
 		},
 	}
 	cmd.Flags().StringVar(&actionID, "action-id", "", "Action ID to query")
 	return cmd
 }
+
+// actionJSON renders an action with the derived fields shared by status and
+// list: expires_at as unix seconds, plus is_public and app_pubkey if present.
+func actionJSON(action *types.Action) map[string]any {
+	payload := map[string]any{
+		"action_id":    action.ID,
+		"state":        action.State,
+		"type":         action.Type,
+		"creator":      action.Creator,
+		"price":        action.Price,
+		"block_height": action.BlockHeight,
+		"expires_at":   action.ExpirationTime.Unix(),
+	}
+	if meta, ok := action.Metadata.(*types.CascadeMetadata); ok && meta != nil {
+		payload["is_public"] = meta.Public
+	}
+	if len(action.AppPubkey) > 0 {
+		payload["app_pubkey"] = base64.StdEncoding.EncodeToString(action.AppPubkey)
+	}
+	return payload
+}
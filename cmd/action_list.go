@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+const defaultActionListLimit = 100
+
+// newActionListCmd lists the actions created by the ICA (or --creator), one
+// gRPC page at a time unless --all is set.
+func newActionListCmd(app *app) *cobra.Command {
+	var (
+		creator   string
+		state     string
+		typ       string
+		minHeight int64
+		maxHeight int64
+		limit     uint64
+		pageKey   string
+		all       bool
+		format    string
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List actions created by the ICA, with state/type/height filters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "jsonl" && format != "table" {
+				return withCode(codeUsage, fmt.Errorf("--format must be one of: jsonl, table (got %q)", format), nil)
+			}
+			var filter client.ActionFilter
+			var err error
+			if state != "" {
+				if filter.State, err = client.ParseActionState(state); err != nil {
+					return withCode(codeUsage, fmt.Errorf("--state: %w", err), nil)
+				}
+			}
+			if typ != "" {
+				if filter.Type, err = client.ParseActionType(typ); err != nil {
+					return withCode(codeUsage, fmt.Errorf("--type: %w", err), nil)
+				}
+			}
+			if minHeight < 0 || maxHeight < 0 || (maxHeight > 0 && minHeight > maxHeight) {
+				return withCode(codeUsage, fmt.Errorf("--min-height and --max-height must form a non-negative range"), nil)
+			}
+			filter.MinHeight, filter.MaxHeight = minHeight, maxHeight
+			if limit == 0 {
+				return withCode(codeUsage, fmt.Errorf("--limit must be positive"), nil)
+			}
+			var key []byte
+			if pageKey != "" {
+				if key, err = base64.StdEncoding.DecodeString(pageKey); err != nil {
+					return withCode(codeUsage, fmt.Errorf("--page-key: %w", err), nil)
+				}
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			// Resolve the ICA address from the controller key if not provided.
+			creator = strings.TrimSpace(creator)
+			if creator == "" {
+				controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+				if err != nil {
+					return err
+				}
+				creator, err = controller.ICAAddress(ctx)
+				controller.Close()
+				if err != nil {
+					return err
+				}
+			}
+			bc, err := client.NewLumeraClient(ctx, cfg, cascClient.Keyring, cfg.Controller.KeyName)
+			if err != nil {
+				return err
+			}
+			defer bc.Close()
+
+			var out actionLister = jsonlActionLister{}
+			if format == "table" {
+				out = newTableActionLister(os.Stdout)
+			}
+			count, scanned := 0, 0
+			for {
+				page, err := client.ListActionsByCreator(ctx, bc.GRPCConn(), creator, filter, key, limit)
+				if err != nil {
+					return withDetails(err, map[string]any{"creator": creator})
+				}
+				for _, action := range page.Actions {
+					if err := out.action(action); err != nil {
+						return err
+					}
+				}
+				count += len(page.Actions)
+				scanned += page.Scanned
+				key = page.NextKey
+				if !all || len(key) == 0 {
+					break
+				}
+			}
+			nextKey := ""
+			if len(key) > 0 {
+				nextKey = base64.StdEncoding.EncodeToString(key)
+			}
+			return out.done(creator, count, scanned, nextKey)
+		},
+	}
+	cmd.Flags().StringVar(&creator, "creator", "", "Creator address to list (default: the ICA address of the controller key)")
+	cmd.Flags().StringVar(&state, "state", "", "Only actions in this state (e.g. pending, done, approved)")
+	cmd.Flags().StringVar(&typ, "type", "", "Only actions of this type (cascade, sense)")
+	cmd.Flags().Int64Var(&minHeight, "min-height", 0, "Only actions registered at or above this block height")
+	cmd.Flags().Int64Var(&maxHeight, "max-height", 0, "Only actions registered at or below this block height")
+	cmd.Flags().Uint64Var(&limit, "limit", defaultActionListLimit, "Actions fetched per gRPC page (filters apply after fetching)")
+	cmd.Flags().StringVar(&pageKey, "page-key", "", "Resume from the next_key of a previous page")
+	cmd.Flags().BoolVar(&all, "all", false, "Follow next_key through all pages")
+	cmd.Flags().StringVar(&format, "format", "jsonl", "Output format: jsonl (one action per line, then a summary) or table")
+	return cmd
+}
+
+// actionLister renders the actions of action list as they are fetched.
+type actionLister interface {
+	action(*types.Action) error
+	done(creator string, count, scanned int, nextKey string) error
+}
+
+// jsonlActionLister prints one JSON line per action and a summary line.
+type jsonlActionLister struct{}
+
+func (jsonlActionLister) action(action *types.Action) error {
+	return writeJSONLine(actionJSON(action))
+}
+
+func (jsonlActionLister) done(creator string, count, scanned int, nextKey string) error {
+	summary := map[string]any{
+		"status":  "ok",
+		"creator": creator,
+		"count":   count,
+		"scanned": scanned,
+	}
+	if nextKey != "" {
+		summary["next_key"] = nextKey
+	}
+	return writeJSONLine(summary)
+}
+
+// tableActionLister prints an aligned table; the next page key goes to stderr.
+type tableActionLister struct {
+	tw *tabwriter.Writer
+}
+
+func newTableActionLister(w io.Writer) *tableActionLister {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION_ID\tSTATE\tTYPE\tHEIGHT\tEXPIRES_AT\tPUBLIC\tPRICE")
+	return &tableActionLister{tw: tw}
+}
+
+func (t *tableActionLister) action(action *types.Action) error {
+	public := "-"
+	if meta, ok := action.Metadata.(*types.CascadeMetadata); ok && meta != nil {
+		public = strconv.FormatBool(meta.Public)
+	}
+	_, err := fmt.Fprintf(t.tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+		action.ID,
+		strings.TrimPrefix(string(action.State), "ACTION_STATE_"),
+		strings.TrimPrefix(string(action.Type), "ACTION_TYPE_"),
+		action.BlockHeight,
+		action.ExpirationTime.UTC().Format(time.RFC3339),
+		public,
+		action.Price,
+	)
+	return err
+}
+
+func (t *tableActionLister) done(_ string, count, scanned int, nextKey string) error {
+	if err := t.tw.Flush(); err != nil {
+		return err
+	}
+	if nextKey != "" {
+		fmt.Fprintf(os.Stderr, "%d of %d actions shown; next page: --page-key %s\n", count, scanned, nextKey)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+	watchUntilTerminal   = "terminal"
+	// actionStateRejected has no constant in sdk-go.
+	actionStateRejected types.ActionState = "ACTION_STATE_REJECTED"
+)
+
+// actionProgress orders the states an action passes through on success.
+// Failed, rejected and expired actions are not on it.
+var actionProgress = map[types.ActionState]int{
+	types.ActionStatePending:    1,
+	types.ActionStateProcessing: 2,
+	types.ActionStateDone:       3,
+	types.ActionStateApproved:   4,
+}
+
+// actionEnded holds the states that end an action off the success path.
+var actionEnded = map[types.ActionState]bool{
+	types.ActionStateFailed:  true,
+	actionStateRejected:      true,
+	types.ActionStateExpired: true,
+}
+
+// newActionWatchCmd follows an action until it reaches the --until state,
+// printing each state transition as a JSON line.
+func newActionWatchCmd(app *app) *cobra.Command {
+	var (
+		actionID string
+		until    string
+		interval time.Duration
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "watch [action-id]",
+		Short: "Follow an action until it reaches a target or terminal state",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			actionID, err = resolveOptionalArg(actionID, args, "action-id")
+			if err != nil {
+				return err
+			}
+			target, err := parseWatchUntil(until)
+			if err != nil {
+				return withCode(codeUsage, fmt.Errorf("--until: %w", err), nil)
+			}
+			if interval <= 0 {
+				return withCode(codeUsage, fmt.Errorf("--interval must be positive"), nil)
+			}
+			if timeout <= 0 {
+				return withCode(codeUsage, fmt.Errorf("--timeout must be positive"), nil)
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			// Reuse the controller keyring and construct a Lumera gRPC client.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			bc, err := client.NewLumeraClient(ctx, cfg, cascClient.Keyring, cfg.Controller.KeyName)
+			if err != nil {
+				return err
+			}
+			defer bc.Close()
+
+			watch := &client.ActionWatch{
+				Conn:        bc.GRPCConn(),
+				RPCEndpoint: cfg.Lumera.RPCEndpoint,
+				Interval:    interval,
+				Done: func(action *types.Action) (bool, error) {
+					return watchFinished(action.State, target)
+				},
+				OnTransition: func(t client.ActionTransition) error {
+					return writeJSONLine(transitionJSON(t))
+				},
+				OnFallback: func(err error) {
+					fmt.Fprintf(os.Stderr, "websocket unavailable (%v); polling every %s\n", err, interval)
+				},
+			}
+			action, err := watch.Run(ctx, actionID)
+			if err != nil {
+				details := map[string]any{"action_id": actionID}
+				if action != nil {
+					details["state"] = action.State
+				}
+				return withDetails(err, details)
+			}
+			details := map[string]any{"action_id": action.ID, "state": action.State}
+			switch action.State {
+			case types.ActionStateFailed, actionStateRejected:
+				return withCode(codeActionFailed, fmt.Errorf("action %s ended in %s", action.ID, action.State), details)
+			case types.ActionStateExpired:
+				return withCode(codeActionExpired, fmt.Errorf("action %s expired at %s", action.ID, action.ExpirationTime.UTC().Format(time.RFC3339)), details)
+			}
+			return writeJSONLine(map[string]any{
+				"status":    "ok",
+				"action_id": action.ID,
+				"state":     action.State,
+				"until":     until,
+			})
+		},
+	}
+	cmd.Flags().StringVar(&actionID, "action-id", "", "Action ID to watch")
+	cmd.Flags().StringVar(&until, "until", watchUntilTerminal, "Stop at this state (processing, done, approved) or at any terminal state (terminal)")
+	cmd.Flags().DurationVar(&interval, "interval", defaultWatchInterval, "Polling interval when the websocket is unavailable")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultCommandTimeout, "Give up after this long")
+	return cmd
+}
+
+// parseWatchUntil returns the target state of --until, or "" for terminal.
+func parseWatchUntil(until string) (types.ActionState, error) {
+	if strings.EqualFold(strings.TrimSpace(until), watchUntilTerminal) {
+		return "", nil
+	}
+	name, err := client.ParseActionState(until)
+	if err != nil {
+		return "", err
+	}
+	state := types.ActionState(name)
+	if state == types.ActionStatePending || actionProgress[state] == 0 {
+		return "", errors.New("must be processing, done, approved or terminal")
+	}
+	return state, nil
+}
+
+// watchFinished reports whether an action in state has reached target, or
+// can no longer reach it. With no target any state past PROCESSING ends the
+// watch; DONE counts as terminal since approval is optional. A state that is
+// neither on the success path nor a known end state, such as UNSPECIFIED, is
+// an error rather than an end.
+func watchFinished(state, target types.ActionState) (bool, error) {
+	if actionEnded[state] {
+		return true, nil
+	}
+	progress, ok := actionProgress[state]
+	if !ok {
+		return false, fmt.Errorf("action is in unrecognized state %q", state)
+	}
+	if target == "" {
+		return progress >= actionProgress[types.ActionStateDone], nil
+	}
+	return progress >= actionProgress[target], nil
+}
+
+// transitionJSON renders a state transition: the action fields of
+// actionJSON plus the block at which the new state was seen.
+func transitionJSON(t client.ActionTransition) map[string]any {
+	payload := actionJSON(t.Action)
+	payload["event"] = "transition"
+	if t.PreviousState != "" {
+		payload["previous_state"] = t.PreviousState
+	}
+	payload["observed_height"] = t.Height
+	payload["observed_at"] = t.Time.UTC().Format(time.RFC3339)
+	payload["source"] = t.Source
+	return payload
+}
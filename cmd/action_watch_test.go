@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/LumeraProtocol/sdk-go/types"
+)
+
+func TestParseWatchUntil(t *testing.T) {
+	cases := []struct {
+		until   string
+		want    types.ActionState
+		wantErr bool
+	}{
+		{"terminal", "", false},
+		{" Terminal ", "", false},
+		{"processing", types.ActionStateProcessing, false},
+		{"done", types.ActionStateDone, false},
+		{"ACTION_STATE_APPROVED", types.ActionStateApproved, false},
+		{"pending", "", true},
+		{"failed", "", true},
+		{"expired", "", true},
+		{"bogus", "", true},
+	}
+	for _, tc := range cases {
+		got, err := parseWatchUntil(tc.until)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("parseWatchUntil(%q) = %q, %v; want %q, error %v", tc.until, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestWatchFinished(t *testing.T) {
+	cases := []struct {
+		state, target types.ActionState
+		want          bool
+		wantErr       bool
+	}{
+		{types.ActionStatePending, "", false, false},
+		{types.ActionStateProcessing, "", false, false},
+		{types.ActionStateDone, "", true, false},
+		{types.ActionStateApproved, "", true, false},
+		{types.ActionStateFailed, "", true, false},
+		{actionStateRejected, "", true, false},
+		{types.ActionStateExpired, "", true, false},
+		{types.ActionStatePending, types.ActionStateProcessing, false, false},
+		{types.ActionStateProcessing, types.ActionStateProcessing, true, false},
+		{types.ActionStateDone, types.ActionStateProcessing, true, false},
+		{types.ActionStateDone, types.ActionStateApproved, false, false},
+		{types.ActionStateApproved, types.ActionStateApproved, true, false},
+		{types.ActionStateFailed, types.ActionStateApproved, true, false},
+		{types.ActionStateExpired, types.ActionStateProcessing, true, false},
+		{"ACTION_STATE_UNSPECIFIED", "", false, true},
+		{"ACTION_STATE_UNSPECIFIED", types.ActionStateApproved, false, true},
+		{"", "", false, true},
+	}
+	for _, tc := range cases {
+		got, err := watchFinished(tc.state, tc.target)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("watchFinished(%q, %q) = %v, %v; want %v, error %v", tc.state, tc.target, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
@@ -2,10 +2,12 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,37 +21,147 @@ import (
 // app bundles CLI-level options and helpers shared across commands.
 type app struct {
 	configPath string
+	profile    string
+	output     string
+	overrides  []string
+
+	passphraseStdin bool
+	// passphrase is the keyring passphrase read by --passphrase-stdin, zeroed on exit.
+	passphrase []byte
 }
 
-const defaultCommandTimeout = 10 * time.Minute
+const (
+	defaultCommandTimeout = 10 * time.Minute
+	maxStdinPassphrase    = 4096
+)
 
-// NewRootCmd builds the root CLI command and registers subcommands.
-func NewRootCmd() *cobra.Command {
+// Execute runs the CLI and returns the process exit code. Failures are reported
+// as text on stderr, or as a JSON error envelope on stdout with --output json.
+func Execute() int {
 	app := &app{}
+	defer func() { clear(app.passphrase) }()
+	cmd := newRootCmd(app)
+	if err := cmd.Execute(); err != nil {
+		return reportError(err, app.output == "json")
+	}
+	return 0
+}
+
+// NewRootCmd builds the root CLI command and registers subcommands. Execute
+// also classifies errors into exit codes; callers of NewRootCmd get the raw error.
+func NewRootCmd() *cobra.Command {
+	return newRootCmd(&app{})
+}
+
+// newRootCmd builds the root command around app.
+func newRootCmd(app *app) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "lumera-ica-client",
-		Short:        "Lumera ICA reference client",
-		SilenceUsage: true,
+		Use:           "lumera-ica-client",
+		Short:         "Lumera ICA reference client",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch app.output {
+			case "text", "json":
+			default:
+				return withCode(codeUsage, fmt.Errorf("--output must be one of: text, json (got %q)", app.output), nil)
+			}
+			if app.passphraseStdin {
+				return app.readPassphrase(cmd)
+			}
+			return nil
+		},
 	}
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return withCode(codeUsage, err, nil)
+	})
 	cmd.PersistentFlags().StringVar(&app.configPath, "config", "config.toml", "Path to config file")
+	cmd.PersistentFlags().StringVar(&app.profile, "profile", "", "Config profile ([profiles.<name>]) applied over the top-level sections")
+	cmd.PersistentFlags().StringArrayVar(&app.overrides, "set", nil, "Override a config field as section.field=value (repeatable; wins over env and file)")
+	cmd.PersistentFlags().BoolVar(&app.passphraseStdin, "passphrase-stdin", false, "Read the controller keyring passphrase from stdin (instead of a controller.keyring_passphrase_* setting)")
+	cmd.PersistentFlags().StringVar(&app.output, "output", "text", "Error output format: text (stderr) or json (error envelope on stdout)")
 	cmd.AddCommand(newUploadCmd(app))
 	cmd.AddCommand(newDownloadCmd(app))
 	cmd.AddCommand(newActionCmd(app))
+	cmd.AddCommand(newICACmd(app))
+	cmd.AddCommand(newResumeCmd(app))
+	cmd.AddCommand(newConfigCmd(app))
+	cmd.AddCommand(newDoctorCmd(app))
+	cmd.AddCommand(newProfilesCmd(app))
+	cmd.AddCommand(newKeysCmd(app))
+	cmd.AddCommand(newSignerCmd(app))
+	usageArgs(cmd)
 	return cmd
 }
 
-// loadConfig resolves the config path and loads the TOML config on demand.
+// usageArgs classifies positional-argument errors of cmd and its subcommands
+// as USAGE. Command groups get NoArgs and a help RunE, so an unknown
+// subcommand fails instead of printing help.
+func usageArgs(cmd *cobra.Command) {
+	if cmd.HasSubCommands() && cmd.Run == nil && cmd.RunE == nil {
+		cmd.Args = cobra.NoArgs
+		cmd.RunE = func(cmd *cobra.Command, _ []string) error { return cmd.Help() }
+	}
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return withCode(codeUsage, validate(cmd, args), nil)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		usageArgs(sub)
+	}
+}
+
+// loadConfig resolves the config path and loads the layered config on demand.
 func (a *app) loadConfig() (*client.Config, error) {
+	cfg, _, err := a.resolveConfig(true)
+	return cfg, err
+}
+
+// resolveConfig loads defaults, the config file and the --profile block, plus
+// LUMERA_ICA_* env vars and --set overrides when withOverrides is true, and
+// reports each value's source.
+func (a *app) resolveConfig(withOverrides bool) (*client.Config, client.ConfigSources, error) {
 	path := strings.TrimSpace(a.configPath)
 	if path == "" {
-		return nil, errors.New("config path is required")
+		return nil, nil, withCode(codeConfigInvalid, errors.New("config path is required"), nil)
 	}
 	path = filepath.Clean(path)
-	cfg, err := client.LoadConfig(path)
+	opts := client.LoadOptions{Profile: a.profile, Passphrase: a.passphrase}
+	if withOverrides {
+		opts.Environ = os.Environ()
+		opts.Overrides = a.overrides
+	}
+	cfg, sources, err := client.LoadConfigWithOptions(path, opts)
+	if err != nil {
+		details := map[string]any{"config": path}
+		if a.profile != "" {
+			details["profile"] = a.profile
+		}
+		return nil, nil, withDetails(err, details)
+	}
+	return cfg, sources, nil
+}
+
+// readPassphrase reads the keyring passphrase for --passphrase-stdin: all of
+// stdin, minus the trailing newline.
+func (a *app) readPassphrase(cmd *cobra.Command) error {
+	data, err := io.ReadAll(io.LimitReader(cmd.InOrStdin(), maxStdinPassphrase))
 	if err != nil {
-		return nil, err
+		clear(data)
+		return withCode(codeUsage, fmt.Errorf("--passphrase-stdin: %w", err), nil)
 	}
-	return cfg, nil
+	a.passphrase = bytes.TrimRight(data, "\r\n")
+	if len(a.passphrase) == 0 {
+		return withCode(codeUsage, errors.New("--passphrase-stdin: stdin is empty"), nil)
+	}
+	return nil
+}
+
+// journalPath returns the upload journal location next to the config file;
+// each profile gets its own journal.
+func (a *app) journalPath() string {
+	return client.ProfileJournalPath(filepath.Clean(strings.TrimSpace(a.configPath)), a.profile)
 }
 
 // commandContext enforces a default timeout for command execution.
@@ -61,6 +173,49 @@ func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, defaultCommandTimeout)
 }
 
+// icaCommandContext is commandContext extended by controller.ack_wait_timeout,
+// so a long ack wait is not cut short by the default command timeout.
+func icaCommandContext(cmd *cobra.Command, cfg *client.Config) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	_, ackWaitTimeout, err := cfg.Controller.ICATimeouts()
+	if err != nil {
+		ackWaitTimeout = 0
+	}
+	return context.WithTimeout(ctx, defaultCommandTimeout+ackWaitTimeout)
+}
+
+// icaTimeoutFlags holds per-command overrides of the controller ICA timeouts.
+type icaTimeoutFlags struct {
+	packetTimeout  time.Duration
+	ackWaitTimeout time.Duration
+}
+
+// register adds --packet-timeout and --ack-wait-timeout to cmd.
+func (f *icaTimeoutFlags) register(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.packetTimeout, "packet-timeout", 0, "ICA packet timeout (overrides controller.packet_timeout)")
+	cmd.Flags().DurationVar(&f.ackWaitTimeout, "ack-wait-timeout", 0, "How long to wait for the ICA ack before reporting it pending (overrides controller.ack_wait_timeout)")
+}
+
+// apply copies explicitly set flags into the controller config.
+func (f *icaTimeoutFlags) apply(cmd *cobra.Command, cfg *client.Config) error {
+	if cmd.Flags().Changed("packet-timeout") {
+		if f.packetTimeout <= 0 {
+			return withCode(codeUsage, fmt.Errorf("--packet-timeout must be positive"), nil)
+		}
+		cfg.Controller.PacketTimeout = f.packetTimeout.String()
+	}
+	if cmd.Flags().Changed("ack-wait-timeout") {
+		if f.ackWaitTimeout <= 0 {
+			return withCode(codeUsage, fmt.Errorf("--ack-wait-timeout must be positive"), nil)
+		}
+		cfg.Controller.AckWaitTimeout = f.ackWaitTimeout.String()
+	}
+	return nil
+}
+
 // writeJSON emits a pretty-printed JSON response to stdout.
 func writeJSON(payload any) error {
 	enc := json.NewEncoder(os.Stdout)
@@ -68,17 +223,22 @@ func writeJSON(payload any) error {
 	return enc.Encode(payload)
 }
 
+// writeJSONLine emits a compact single-line JSON record to stdout.
+func writeJSONLine(payload any) error {
+	return json.NewEncoder(os.Stdout).Encode(payload)
+}
+
 // resolveOptionalArg accepts either a flag or positional value for a field.
 func resolveOptionalArg(flagValue string, args []string, name string) (string, error) {
 	flagValue = strings.TrimSpace(flagValue)
 	if flagValue != "" {
 		if len(args) > 0 {
-			return "", fmt.Errorf("%s provided both as flag and argument", name)
+			return "", withCode(codeUsage, fmt.Errorf("%s provided both as flag and argument", name), nil)
 		}
 		return flagValue, nil
 	}
 	if len(args) > 0 {
 		return strings.TrimSpace(args[0]), nil
 	}
-	return "", fmt.Errorf("%s is required", name)
+	return "", withCode(codeUsage, fmt.Errorf("%s is required", name), nil)
 }
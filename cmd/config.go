@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+const redacted = "[redacted]"
+
+// newConfigCmd groups config inspection and generation subcommands.
+func newConfigCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Configuration commands",
+	}
+	cmd.AddCommand(newConfigShowCmd(app))
+	cmd.AddCommand(newConfigConvertCmd())
+	cmd.AddCommand(newConfigInitCmd(app))
+	return cmd
+}
+
+// newConfigShowCmd prints the effective config with secrets redacted and the
+// layer (default, file, env, flag) each value came from.
+func newConfigShowCmd(app *app) *cobra.Command {
+	var resolved bool
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective config and where each value came from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, sources, err := app.resolveConfig(resolved)
+			if err != nil {
+				return err
+			}
+			values := map[string]any{}
+			for _, v := range cfg.Values(sources) {
+				value := v.Value
+				if v.Secret && value != "" {
+					value = redacted
+				}
+				entry := map[string]any{"value": value, "source": v.Source}
+				if v.Source == client.SourceEnv {
+					entry["env"] = client.EnvName(v.Key)
+				}
+				values[v.Key] = entry
+			}
+			return writeJSON(map[string]any{
+				"status":   "ok",
+				"config":   filepath.Clean(strings.TrimSpace(app.configPath)),
+				"profile":  app.profile,
+				"resolved": resolved,
+				"values":   values,
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&resolved, "resolved", false, "Apply LUMERA_ICA_* environment variables and --set overrides")
+	return cmd
+}
+
+// newConfigConvertCmd translates a config file between TOML, YAML and JSON.
+func newConfigConvertCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "convert <src> <dst>",
+		Short: "Convert a config file between TOML, YAML and JSON (format from extension)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, dst := filepath.Clean(args[0]), filepath.Clean(args[1])
+			if err := client.ConvertConfigFile(src, dst, force); err != nil {
+				return err
+			}
+			srcFormat, _ := client.ConfigFormatFromPath(src)
+			dstFormat, _ := client.ConfigFormatFromPath(dst)
+			return writeJSON(map[string]any{
+				"status":      "ok",
+				"source":      src,
+				"destination": dst,
+				"from":        srcFormat,
+				"to":          dstFormat,
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the destination file if it exists")
+	return cmd
+}
@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"lumera-ica-client/client"
+)
+
+// initField binds a config field to its flag, prompt label and default.
+// defFrom computes the default from fields filled earlier in the same call.
+type initField struct {
+	flag     string
+	label    string
+	target   *string
+	def      string
+	defFrom  func() string
+	required bool
+}
+
+// newConfigInitCmd generates a commented config. Values come from flags or, on a
+// terminal, prompts; the IBC connection pair is discovered from both chains and the
+// chosen keys are checked against the keyring before the file is written.
+func newConfigInitCmd(app *app) *cobra.Command {
+	var cfg client.Config
+	var connectionID, counterpartyConnectionID string
+	var force, noPrompt bool
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a validated config from flags or interactive prompts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := filepath.Clean(strings.TrimSpace(app.configPath))
+			in := cmd.InOrStdin()
+			p := &prompter{
+				in:          bufio.NewReader(in),
+				out:         cmd.ErrOrStderr(),
+				interactive: !noPrompt && isTerminal(in),
+			}
+			cfg.Lumera.LogLevel = "info"
+
+			// Chains and endpoints.
+			if err := p.fill([]initField{
+				{flag: "lumera-chain-id", label: "Lumera chain ID", target: &cfg.Lumera.ChainID, required: true},
+				{flag: "lumera-grpc", label: "Lumera gRPC endpoint", target: &cfg.Lumera.GRPCEndpoint, required: true},
+				{flag: "lumera-rpc", label: "Lumera RPC endpoint", target: &cfg.Lumera.RPCEndpoint, required: true},
+				{flag: "controller-chain-id", label: "Controller chain ID", target: &cfg.Controller.ChainID, required: true},
+				{flag: "controller-grpc", label: "Controller gRPC endpoint", target: &cfg.Controller.GRPCEndpoint, required: true},
+				{flag: "controller-rpc", label: "Controller RPC endpoint", target: &cfg.Controller.RPCEndpoint, required: true},
+				{flag: "account-hrp", label: "Controller bech32 HRP", target: &cfg.Controller.AccountHRP, required: true},
+				{flag: "gas-prices", label: "Controller gas price (e.g. 0.03uosmo)", target: &cfg.Controller.GasPrices},
+				{flag: "controller-binary", label: "Controller binary (optional)", target: &cfg.Controller.Binary},
+				{flag: "controller-home", label: "Controller home (optional)", target: &cfg.Controller.Home},
+			}); err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			// Connection pair: explicit flags win, otherwise discover it on both chains.
+			if connectionID == "" || counterpartyConnectionID == "" {
+				pair, err := p.chooseConnection(ctx, &cfg, connectionID)
+				if err != nil {
+					return err
+				}
+				connectionID, counterpartyConnectionID = pair.ConnectionID, pair.CounterpartyConnectionID
+			}
+			cfg.Controller.ConnectionID = connectionID
+			cfg.Controller.CounterpartyConnectionID = counterpartyConnectionID
+
+			// Keyring location. Only the prompt suggests the test backend; without
+			// prompts the backend must be chosen explicitly.
+			backendDef := ""
+			if p.interactive {
+				backendDef = "test"
+			}
+			if err := p.fill([]initField{
+				{flag: "keyring-backend", label: "Keyring backend (os, file, test)", target: &cfg.Controller.KeyringBackend, def: backendDef, required: true},
+			}); err != nil {
+				return err
+			}
+			cfg.Controller.KeyringBackend = strings.ToLower(strings.TrimSpace(cfg.Controller.KeyringBackend))
+			if err := p.fill([]initField{
+				{flag: "keyring-dir", label: "Keyring directory", target: &cfg.Controller.KeyringDir, required: cfg.Controller.KeyringBackend == "file"},
+				{flag: "keyring-passphrase-file", label: "Keyring passphrase file (optional)", target: &cfg.Controller.KeyringPassphraseFile},
+			}); err != nil {
+				return err
+			}
+			if app.passphrase != nil && cfg.Controller.KeyringPassphraseFile != "" {
+				return withCode(codeUsage, errors.New("--passphrase-stdin and --keyring-passphrase-file are mutually exclusive"), nil)
+			}
+			expanded := cfg
+			if err := expanded.ExpandPaths(); err != nil {
+				return err
+			}
+			expanded.Controller.SetStdinPassphrase(app.passphrase)
+			kr, err := client.OpenControllerKeyring(expanded.Controller)
+			if err != nil {
+				return err
+			}
+			keys, err := client.ListKeys(kr, cfg.Controller.AccountHRP)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				return withCode(codeKeyNotFound, fmt.Errorf("keyring (%s backend) has no keys; add one with the controller chain binary first", cfg.Controller.KeyringBackend), nil)
+			}
+			p.printKeys(keys)
+
+			// Keys: the Lumera key defaults to the controller key.
+			defaultKey := ""
+			if len(keys) == 1 {
+				defaultKey = keys[0].Name
+			}
+			if err := p.fill([]initField{
+				{flag: "key-name", label: "Controller key name", target: &cfg.Controller.KeyName, def: defaultKey, required: true},
+				{flag: "key-type", label: "Controller key type (cosmos, evm)", target: &cfg.Controller.KeyType, defFrom: func() string { return keyTypeOf(keys, cfg.Controller.KeyName) }, required: true},
+				{flag: "lumera-key-name", label: "Lumera key name", target: &cfg.Lumera.KeyName, defFrom: func() string { return cfg.Controller.KeyName }, required: true},
+				{flag: "lumera-key-type", label: "Lumera key type (cosmos, evm)", target: &cfg.Lumera.KeyType, defFrom: func() string { return keyTypeOf(keys, cfg.Lumera.KeyName) }, required: true},
+			}); err != nil {
+				return withDetails(err, map[string]any{"keys": keyNames(keys)})
+			}
+			if err := client.ValidateKeys(kr, &cfg); err != nil {
+				return withDetails(err, map[string]any{"keys": keyNames(keys)})
+			}
+
+			if err := client.WriteNewConfig(out, &cfg, force); err != nil {
+				return withDetails(err, map[string]any{"config": out})
+			}
+			return writeJSON(map[string]any{
+				"status":                     "ok",
+				"config":                     out,
+				"connection_id":              cfg.Controller.ConnectionID,
+				"counterparty_connection_id": cfg.Controller.CounterpartyConnectionID,
+				"controller_key":             cfg.Controller.KeyName,
+				"lumera_key":                 cfg.Lumera.KeyName,
+			})
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&cfg.Lumera.ChainID, "lumera-chain-id", "", "Lumera chain ID")
+	f.StringVar(&cfg.Lumera.GRPCEndpoint, "lumera-grpc", "", "Lumera gRPC endpoint")
+	f.StringVar(&cfg.Lumera.RPCEndpoint, "lumera-rpc", "", "Lumera RPC endpoint")
+	f.StringVar(&cfg.Lumera.KeyName, "lumera-key-name", "", "Key used for Lumera signing (default: --key-name)")
+	f.StringVar(&cfg.Lumera.KeyType, "lumera-key-type", "", "Lumera key type: cosmos or evm (default: --key-type)")
+	f.StringVar(&cfg.Controller.ChainID, "controller-chain-id", "", "Controller chain ID")
+	f.StringVar(&cfg.Controller.GRPCEndpoint, "controller-grpc", "", "Controller gRPC endpoint")
+	f.StringVar(&cfg.Controller.RPCEndpoint, "controller-rpc", "", "Controller CometBFT RPC endpoint")
+	f.StringVar(&cfg.Controller.AccountHRP, "account-hrp", "", "Bech32 HRP for controller addresses")
+	f.StringVar(&cfg.Controller.GasPrices, "gas-prices", "", "Gas price for controller txs, e.g. 0.03uosmo")
+	f.StringVar(&cfg.Controller.Binary, "controller-binary", "", "Controller chain binary (sets the keyring app name)")
+	f.StringVar(&cfg.Controller.Home, "controller-home", "", "Controller chain home directory")
+	f.StringVar(&cfg.Controller.KeyringBackend, "keyring-backend", "", "Keyring backend: os, file or test (required without prompts)")
+	f.StringVar(&cfg.Controller.KeyringDir, "keyring-dir", "", "Keyring directory (required for the file backend)")
+	f.StringVar(&cfg.Controller.KeyringPassphraseFile, "keyring-passphrase-file", "", "File holding the keyring passphrase")
+	f.StringVar(&cfg.Controller.KeyName, "key-name", "", "Controller key used to sign ICA txs")
+	f.StringVar(&cfg.Controller.KeyType, "key-type", "", "Controller key type: cosmos or evm (default: detected from the key)")
+	f.StringVar(&connectionID, "connection-id", "", "Controller-side IBC connection to Lumera (default: discovered)")
+	f.StringVar(&counterpartyConnectionID, "counterparty-connection-id", "", "Lumera-side IBC connection (with --connection-id, skips discovery)")
+	f.BoolVar(&force, "force", false, "Overwrite the config file if it exists")
+	f.BoolVar(&noPrompt, "no-prompt", false, "Never prompt; fail when a required value is missing")
+	return cmd
+}
+
+// prompter asks for missing values on stderr when stdin is a terminal.
+type prompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+}
+
+// fill sets empty fields from prompts or defaults and reports required fields
+// that are still missing as a usage error naming their flags.
+func (p *prompter) fill(fields []initField) error {
+	var missing []string
+	for _, f := range fields {
+		*f.target = strings.TrimSpace(*f.target)
+		if *f.target != "" {
+			continue
+		}
+		value := f.def
+		if f.defFrom != nil {
+			value = f.defFrom()
+		}
+		if p.interactive {
+			answer, err := p.ask(f.label, value)
+			if err != nil {
+				return err
+			}
+			value = answer
+		}
+		*f.target = value
+		if value == "" && f.required {
+			missing = append(missing, "--"+f.flag)
+		}
+	}
+	if len(missing) > 0 {
+		return withCode(codeUsage, fmt.Errorf("missing required values: %s", strings.Join(missing, ", ")), nil)
+	}
+	return nil
+}
+
+// ask prints a prompt with an optional default and reads one line.
+func (p *prompter) ask(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("read %s: %w", strings.ToLower(label), err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// printKeys lists keyring keys on stderr in interactive mode.
+func (p *prompter) printKeys(keys []client.KeyringKey) {
+	if !p.interactive {
+		return
+	}
+	fmt.Fprintln(p.out, "Keys in the keyring:")
+	for _, k := range keys {
+		kt := k.KeyType
+		if kt == "" {
+			kt = k.Algo
+		}
+		fmt.Fprintf(p.out, "  %-20s %-8s %s\n", k.Name, kt, k.Address)
+	}
+}
+
+// chooseConnection discovers the connection pairs between the two chains and
+// picks the one matching connectionID, the only one, or the one the user selects.
+func (p *prompter) chooseConnection(ctx context.Context, cfg *client.Config, connectionID string) (client.ConnectionPair, error) {
+	pairs, err := client.DiscoverConnections(ctx, cfg)
+	if err != nil {
+		return client.ConnectionPair{}, err
+	}
+	var ids []string
+	for _, pair := range pairs {
+		if connectionID != "" && pair.ConnectionID == connectionID {
+			return pair, nil
+		}
+		ids = append(ids, pair.ConnectionID+"/"+pair.CounterpartyConnectionID)
+	}
+	details := map[string]any{"connections": ids}
+	switch {
+	case connectionID != "":
+		return client.ConnectionPair{}, withCode(codeUsage, fmt.Errorf("connection %s is not an open connection between %s and %s", connectionID, cfg.Controller.ChainID, cfg.Lumera.ChainID), details)
+	case len(pairs) == 0:
+		return client.ConnectionPair{}, fmt.Errorf("no open IBC connection between %s and %s", cfg.Controller.ChainID, cfg.Lumera.ChainID)
+	case len(pairs) == 1:
+		return pairs[0], nil
+	case !p.interactive:
+		return client.ConnectionPair{}, withCode(codeUsage, fmt.Errorf("%d open connections between %s and %s; pick one with --connection-id", len(pairs), cfg.Controller.ChainID, cfg.Lumera.ChainID), details)
+	}
+	fmt.Fprintln(p.out, "Open connections (controller/lumera):")
+	for i, id := range ids {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, id)
+	}
+	answer, err := p.ask("Connection number", "1")
+	if err != nil {
+		return client.ConnectionPair{}, err
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(pairs) {
+		return client.ConnectionPair{}, withCode(codeUsage, fmt.Errorf("connection number must be between 1 and %d", len(pairs)), nil)
+	}
+	return pairs[n-1], nil
+}
+
+// isTerminal reports whether r is a file attached to a terminal.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// keyTypeOf returns the detected key type of the named key, or "".
+func keyTypeOf(keys []client.KeyringKey, name string) string {
+	for _, k := range keys {
+		if k.Name == name {
+			return k.KeyType
+		}
+	}
+	return ""
+}
+
+// keyNames lists key names for error details.
+func keyNames(keys []client.KeyringKey) []string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.Name
+	}
+	return names
+}
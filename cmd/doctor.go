@@ -0,0 +1,384 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+const defaultCheckTimeout = 15 * time.Second
+
+// checkStatus is the outcome of a single doctor check.
+type checkStatus string
+
+const (
+	checkPass checkStatus = "pass"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+	checkSkip checkStatus = "skip"
+)
+
+// checkResult is one line of the doctor report.
+type checkResult struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+	Hint    string      `json:"hint,omitempty"`
+}
+
+// newDoctorCmd runs connectivity and configuration checks against the loaded
+// config and exits non-zero when any check fails.
+func newDoctorCmd(app *app) *cobra.Command {
+	var checkTimeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose config, keys, chain connectivity, ICA state and supernodes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if checkTimeout <= 0 {
+				return withCode(codeUsage, fmt.Errorf("--check-timeout must be positive"), nil)
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+			d := &doctor{app: app, timeout: checkTimeout}
+			d.run(ctx)
+			if err := writeJSON(d.report()); err != nil {
+				return err
+			}
+			if failed := d.failed(); len(failed) > 0 {
+				return withCode(codeChecksFailed, fmt.Errorf("%d of %d checks failed: %s", len(failed), len(d.results), strings.Join(failed, ", ")),
+					map[string]any{"failed_checks": failed})
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&checkTimeout, "check-timeout", defaultCheckTimeout, "Timeout for each network check")
+	return cmd
+}
+
+// doctor runs the checks in dependency order; a check whose prerequisite
+// failed is reported as skipped.
+type doctor struct {
+	app     *app
+	timeout time.Duration
+	results []checkResult
+}
+
+// report summarizes the results; status is error on any failure, warn on any warning.
+func (d *doctor) report() map[string]any {
+	counts := map[checkStatus]int{}
+	for _, r := range d.results {
+		counts[r.Status]++
+	}
+	status := "ok"
+	switch {
+	case counts[checkFail] > 0:
+		status = "error"
+	case counts[checkWarn] > 0:
+		status = "warn"
+	}
+	return map[string]any{
+		"status":  status,
+		"checks":  d.results,
+		"passed":  counts[checkPass],
+		"warned":  counts[checkWarn],
+		"failed":  counts[checkFail],
+		"skipped": counts[checkSkip],
+	}
+}
+
+// failed returns the names of failed checks.
+func (d *doctor) failed() []string {
+	var names []string
+	for _, r := range d.results {
+		if r.Status == checkFail {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+func (d *doctor) add(name string, status checkStatus, message, hint string) {
+	d.results = append(d.results, checkResult{Name: name, Status: status, Message: message, Hint: hint})
+}
+
+func (d *doctor) skip(reason string, names ...string) {
+	for _, name := range names {
+		d.add(name, checkSkip, reason, "")
+	}
+}
+
+// bounded runs fn with the per-check timeout.
+func (d *doctor) bounded(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func (d *doctor) run(ctx context.Context) {
+	cfg, err := d.app.loadConfig()
+	if err != nil {
+		hint := "run `config init` to generate a config"
+		var cfgErr *client.ConfigError
+		if errors.As(err, &cfgErr) && cfgErr.Field != "" {
+			hint = fmt.Sprintf("fix %s in %s", cfgErr.Field, d.app.configPath)
+		}
+		d.add("config", checkFail, err.Error(), hint)
+		d.skip("config did not load", "keyring", "keys", "lumera_grpc", "cascade_client", "controller_grpc",
+			"connection", "ica_registered", "ica_channel", "ica_funded", "supernodes")
+		return
+	}
+	d.add("config", checkPass, "loaded "+d.app.configPath, "")
+
+	// Keyring and keys.
+	kr, keysOK := d.checkKeys(cfg)
+
+	// Lumera chain.
+	var bc *blockchain.Client
+	err = d.bounded(ctx, func(ctx context.Context) error {
+		bc, err = client.NewLumeraClient(ctx, cfg, kr, cfg.Lumera.KeyName)
+		if err != nil {
+			return err
+		}
+		chainID, err := client.NodeChainID(ctx, bc.GRPCConn())
+		if err != nil {
+			return err
+		}
+		return checkChainID(chainID, cfg.Lumera.ChainID)
+	})
+	if bc != nil {
+		defer bc.Close()
+	}
+	lumeraOK := d.record("lumera_grpc", err, "lumera "+cfg.Lumera.GRPCEndpoint+" serves "+cfg.Lumera.ChainID,
+		"check lumera.grpc_endpoint and lumera.chain_id")
+
+	if !keysOK || !lumeraOK {
+		d.skip("keys or lumera_grpc failed", "cascade_client")
+	} else {
+		err = d.bounded(ctx, func(ctx context.Context) error {
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			return cascClient.Cascade.Close()
+		})
+		d.record("cascade_client", err, "cascade client initialized", "check the [lumera] section and the lumera key")
+	}
+
+	// Controller chain and ICA state.
+	if !keysOK {
+		d.skip("keys failed", "controller_grpc", "connection", "ica_registered", "ica_channel", "ica_funded")
+	} else {
+		d.checkController(ctx, cfg, kr)
+	}
+
+	// Supernodes.
+	if !lumeraOK {
+		d.skip("lumera_grpc failed", "supernodes")
+		return
+	}
+	d.checkSupernodes(ctx, bc)
+}
+
+// record adds a pass or fail result for err and reports whether it passed.
+func (d *doctor) record(name string, err error, okMessage, hint string) bool {
+	if err != nil {
+		d.add(name, checkFail, err.Error(), hint)
+		return false
+	}
+	d.add(name, checkPass, okMessage, "")
+	return true
+}
+
+// checkChainID compares the chain ID reported by a node with the configured one.
+func checkChainID(chainID, expected string) error {
+	if chainID != expected {
+		return fmt.Errorf("node serves chain %q, config expects %q", chainID, expected)
+	}
+	return nil
+}
+
+func (d *doctor) checkKeys(cfg *client.Config) (keyring.Keyring, bool) {
+	kr, err := client.OpenControllerKeyring(cfg.Controller)
+	message := "opened " + cfg.Controller.KeyringBackend + " keyring"
+	hint := "check controller.keyring_backend, keyring_dir/home and the passphrase source"
+	if cfg.Controller.RemoteSigner() {
+		message = "loaded keys from remote signer " + cfg.Controller.SignerEndpoint
+		hint = "check controller.signer_endpoint, the signer TLS settings and that the signer is running"
+	}
+	if !d.record("keyring", err, message, hint) {
+		d.skip("keyring failed", "keys")
+		return nil, false
+	}
+	err = client.ValidateKeys(kr, cfg)
+	if err == nil {
+		var appKR keyring.Keyring
+		if appKR, err = client.OpenAppKeyring(cfg, kr); err == nil {
+			err = client.ValidateAppKey(appKR, cfg)
+		}
+	}
+	hint = ""
+	switch {
+	case errors.Is(err, client.ErrKeyNotFound):
+		hint = "add it with `keys add --recover` / `keys import`, or fix controller.key_name / lumera.key_name / app_key.key_name"
+	case errors.Is(err, client.ErrKeyTypeMismatch):
+		hint = "set key_type to match the key algorithm (cosmos = secp256k1, evm = eth_secp256k1)"
+	}
+	message = fmt.Sprintf("keys %q and %q match their key types", cfg.Controller.KeyName, cfg.Lumera.KeyName)
+	if cfg.AppKey.Enabled() {
+		message = fmt.Sprintf("keys %q, %q and app key %q match their key types", cfg.Controller.KeyName, cfg.Lumera.KeyName, cfg.AppKey.KeyName)
+	}
+	ok := d.record("keys", err, message, hint)
+	return kr, ok
+}
+
+func (d *doctor) checkController(ctx context.Context, cfg *client.Config, kr keyring.Keyring) {
+	var controller *client.Controller
+	err := d.bounded(ctx, func(ctx context.Context) error {
+		var err error
+		controller, err = client.NewICAController(ctx, cfg, kr)
+		if err != nil {
+			return err
+		}
+		chainID, err := controller.ControllerChainID(ctx)
+		if err != nil {
+			return err
+		}
+		return checkChainID(chainID, cfg.Controller.ChainID)
+	})
+	if controller != nil {
+		defer controller.Close()
+	}
+	if !d.record("controller_grpc", err, "controller "+cfg.Controller.GRPCEndpoint+" serves "+cfg.Controller.ChainID,
+		"check controller.grpc_endpoint and controller.chain_id") {
+		d.skip("controller_grpc failed", "connection", "ica_registered", "ica_channel", "ica_funded")
+		return
+	}
+
+	// IBC connection.
+	var conn *connectiontypes.ConnectionEnd
+	err = d.bounded(ctx, func(ctx context.Context) error {
+		conn, err = controller.Connection(ctx)
+		return err
+	})
+	switch {
+	case err != nil:
+		d.add("connection", checkFail, err.Error(), "run `config init` to discover the connection pair")
+	case conn.State != connectiontypes.OPEN:
+		d.add("connection", checkFail, fmt.Sprintf("%s is %s", cfg.Controller.ConnectionID, conn.State), "use an OPEN connection to Lumera; `config init` can discover it")
+	case cfg.Controller.CounterpartyConnectionID != "" && conn.Counterparty.ConnectionId != cfg.Controller.CounterpartyConnectionID:
+		d.add("connection", checkFail,
+			fmt.Sprintf("%s pairs with %s on Lumera, config says %s", cfg.Controller.ConnectionID, conn.Counterparty.ConnectionId, cfg.Controller.CounterpartyConnectionID),
+			"set controller.counterparty_connection_id to "+conn.Counterparty.ConnectionId)
+	default:
+		d.add("connection", checkPass, fmt.Sprintf("%s is OPEN (Lumera side %s)", cfg.Controller.ConnectionID, conn.Counterparty.ConnectionId), "")
+	}
+	if d.results[len(d.results)-1].Status == checkFail {
+		d.skip("connection failed", "ica_registered", "ica_channel", "ica_funded")
+		return
+	}
+
+	// ICA address and channel.
+	var icaAddress string
+	err = d.bounded(ctx, func(ctx context.Context) error {
+		icaAddress, err = controller.ICAAddress(ctx)
+		return err
+	})
+	if !d.record("ica_registered", err, "ica address "+icaAddress, "run `ica register`") {
+		d.skip("ica_registered failed", "ica_channel", "ica_funded")
+		return
+	}
+	var ch *client.ICAChannel
+	err = d.bounded(ctx, func(ctx context.Context) error {
+		ch, err = controller.ICAChannel(ctx)
+		return err
+	})
+	switch {
+	case err != nil:
+		d.add("ica_channel", checkFail, err.Error(), "")
+	case ch == nil:
+		d.add("ica_channel", checkFail, "no ica channel on "+cfg.Controller.ConnectionID, "run `ica register`")
+	case ch.State == channeltypes.CLOSED:
+		d.add("ica_channel", checkFail, fmt.Sprintf("%s/%s is CLOSED", ch.PortID, ch.ChannelID), "run `ica reopen`")
+	case ch.State != channeltypes.OPEN:
+		d.add("ica_channel", checkWarn, fmt.Sprintf("%s/%s is %s", ch.PortID, ch.ChannelID, ch.State), "the channel handshake is still in progress; check the relayer")
+	default:
+		d.add("ica_channel", checkPass, fmt.Sprintf("%s/%s is OPEN", ch.PortID, ch.ChannelID), "")
+	}
+
+	// ICA balance on Lumera.
+	d.checkFunding(ctx, cfg, controller, icaAddress)
+}
+
+func (d *doctor) checkFunding(ctx context.Context, cfg *client.Config, controller *client.Controller, icaAddress string) {
+	var balances sdk.Coins
+	err := d.bounded(ctx, func(ctx context.Context) error {
+		var err error
+		balances, err = controller.ICABalances(ctx, icaAddress)
+		return err
+	})
+	if err != nil {
+		d.add("ica_funded", checkFail, err.Error(), "")
+		return
+	}
+	if !cfg.Funding.Enabled() {
+		if balances.IsZero() {
+			d.add("ica_funded", checkFail, "ica "+icaAddress+" has no balance", "run `ica fund` or configure [funding]")
+			return
+		}
+		d.add("ica_funded", checkPass, "ica balance "+balances.String(), "")
+		return
+	}
+	minBalance, _, err := cfg.Funding.Thresholds()
+	if err != nil {
+		d.add("ica_funded", checkFail, err.Error(), "")
+		return
+	}
+	if balances.AmountOf(minBalance.Denom).LT(minBalance.Amount) {
+		d.add("ica_funded", checkWarn, fmt.Sprintf("ica balance %s is below funding.min_balance %s", balances, minBalance),
+			"the next upload or approve tops it up; run `ica fund` to do it now")
+		return
+	}
+	d.add("ica_funded", checkPass, "ica balance "+balances.String(), "")
+}
+
+func (d *doctor) checkSupernodes(ctx context.Context, bc *blockchain.Client) {
+	var probes []client.SupernodeProbe
+	err := d.bounded(ctx, func(ctx context.Context) error {
+		var err error
+		probes, err = client.ProbeSupernodes(ctx, bc)
+		return err
+	})
+	if err != nil {
+		d.add("supernodes", checkFail, err.Error(), "check lumera.grpc_endpoint")
+		return
+	}
+	var unreachable []string
+	for _, p := range probes {
+		if p.Err != nil {
+			unreachable = append(unreachable, p.Endpoint)
+		}
+	}
+	reachable := len(probes) - len(unreachable)
+	switch {
+	case len(probes) == 0:
+		d.add("supernodes", checkFail, "no active supernodes for the latest block", "uploads cannot proceed until supernodes are active")
+	case reachable == 0:
+		d.add("supernodes", checkFail, fmt.Sprintf("none of %d active supernodes is reachable", len(probes)), "check outbound network access to supernode ports")
+	case len(unreachable) > 0:
+		d.add("supernodes", checkWarn, fmt.Sprintf("%d of %d active supernodes reachable; unreachable: %s", reachable, len(probes), strings.Join(unreachable, ", ")), "")
+	default:
+		d.add("supernodes", checkPass, fmt.Sprintf("%d active supernodes reachable", len(probes)), "")
+	}
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"lumera-ica-client/client"
+)
+
+// errorCode is a stable, machine-readable failure class reported in the error
+// envelope. Each code maps to a distinct process exit code.
+type errorCode string
+
+const (
+	codeInternal              errorCode = "INTERNAL"
+	codeUsage                 errorCode = "USAGE"
+	codeConfigInvalid         errorCode = "CONFIG_INVALID"
+	codeKeyNotFound           errorCode = "KEY_NOT_FOUND"
+	codeKeyTypeMismatch       errorCode = "KEY_TYPE_MISMATCH"
+	codeICANotRegistered      errorCode = "ICA_NOT_REGISTERED"
+	codeICAChannelClosed      errorCode = "ICA_CHANNEL_CLOSED"
+	codeInsufficientBalance   errorCode = "INSUFFICIENT_ICA_BALANCE"
+	codeAckTimeout            errorCode = "ACK_TIMEOUT"
+	codeAckError              errorCode = "ACK_ERROR"
+	codePacketTimeout         errorCode = "PACKET_TIMEOUT"
+	codeActionNotPending      errorCode = "ACTION_NOT_PENDING"
+	codeSupernodeUploadFailed errorCode = "SUPERNODE_UPLOAD_FAILED"
+	codeTimeout               errorCode = "TIMEOUT"
+	codeChecksFailed          errorCode = "CHECKS_FAILED"
+	codeRemoteSignerFailed    errorCode = "REMOTE_SIGNER_FAILED"
+	codeWrongPassphrase       errorCode = "WRONG_PASSPHRASE"
+	codeActionFailed          errorCode = "ACTION_FAILED"
+	codeActionExpired         errorCode = "ACTION_EXPIRED"
+)
+
+// exitCodes assigns each error code its process exit code. Values are stable.
+var exitCodes = map[errorCode]int{
+	codeInternal:              1,
+	codeUsage:                 2,
+	codeConfigInvalid:         3,
+	codeKeyNotFound:           4,
+	codeKeyTypeMismatch:       5,
+	codeICANotRegistered:      6,
+	codeICAChannelClosed:      7,
+	codeInsufficientBalance:   8,
+	codeAckTimeout:            9,
+	codeAckError:              10,
+	codePacketTimeout:         11,
+	codeActionNotPending:      12,
+	codeSupernodeUploadFailed: 13,
+	codeTimeout:               14,
+	codeChecksFailed:          15,
+	codeRemoteSignerFailed:    16,
+	codeWrongPassphrase:       17,
+	codeActionFailed:          18,
+	codeActionExpired:         19,
+}
+
+// codedError attaches an error code and/or envelope details to an error.
+// An empty code keeps the classification of the wrapped error.
+type codedError struct {
+	code    errorCode
+	details map[string]any
+	err     error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+
+func (e *codedError) Unwrap() error { return e.err }
+
+// withCode classifies err explicitly; details may be nil.
+func withCode(code errorCode, err error, details map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, details: details, err: err}
+}
+
+// withDetails adds envelope details to err without changing its classification.
+func withDetails(err error, details map[string]any) error {
+	return withCode("", err, details)
+}
+
+// classifyError maps an error to its code and envelope details.
+// Typed client errors are recognized first; an explicit codedError wins.
+func classifyError(err error) (errorCode, map[string]any) {
+	code := codeInternal
+	details := map[string]any{}
+	var (
+		balanceErr *client.InsufficientBalanceError
+		pendingErr *client.AckPendingError
+		timeoutErr *client.PacketTimeoutError
+		closedErr  *client.ChannelClosedError
+		keyTypeErr *client.KeyTypeMismatchError
+		ackErr     *client.AckError
+		configErr  *client.ConfigError
+		signerErr  *client.RemoteSignerError
+		unlockErr  *client.KeyringUnlockError
+	)
+	switch {
+	case errors.As(err, &balanceErr):
+		code = codeInsufficientBalance
+		details["ica_address"] = balanceErr.ICAAddress
+		details["denom"] = balanceErr.Denom
+		details["required"] = balanceErr.Required.String()
+		details["available"] = balanceErr.Available.String()
+		details["shortfall"] = balanceErr.Shortfall().String()
+	case errors.As(err, &pendingErr):
+		code = codeAckTimeout
+		details["tx_hash"] = pendingErr.Ref.TxHash
+		details["packet_port"] = pendingErr.Ref.Port
+		details["packet_channel"] = pendingErr.Ref.Channel
+		details["packet_sequence"] = pendingErr.Ref.Sequence
+		details["waited"] = pendingErr.Waited.String()
+	case errors.As(err, &timeoutErr):
+		code = codePacketTimeout
+		details["tx_hash"] = timeoutErr.Ref.TxHash
+		details["packet_port"] = timeoutErr.Ref.Port
+		details["packet_channel"] = timeoutErr.Ref.Channel
+		details["packet_sequence"] = timeoutErr.Ref.Sequence
+	case errors.As(err, &closedErr):
+		code = codeICAChannelClosed
+		details["port_id"] = closedErr.PortID
+		details["channel_id"] = closedErr.ChannelID
+	case errors.As(err, &keyTypeErr):
+		code = codeKeyTypeMismatch
+		details["key_name"] = keyTypeErr.KeyName
+		details["expected"] = keyTypeErr.Expected
+		details["actual"] = keyTypeErr.Actual
+	case errors.As(err, &ackErr):
+		code = codeAckError
+		details["ack_code"] = ackErr.Code
+		details["ack_log"] = ackErr.Log
+	case errors.As(err, &configErr):
+		code = codeConfigInvalid
+		if configErr.Field != "" {
+			details["field"] = configErr.Field
+		}
+	case errors.As(err, &unlockErr):
+		// A missing passphrase source is a config problem.
+		code = codeConfigInvalid
+		if errors.Is(unlockErr, client.ErrWrongPassphrase) {
+			code = codeWrongPassphrase
+			details["passphrase_source"] = unlockErr.Source
+			details["attempts"] = unlockErr.Attempts
+		}
+		details["keyring_dir"] = unlockErr.Dir
+	case errors.Is(err, client.ErrKeyNotFound):
+		code = codeKeyNotFound
+	case errors.Is(err, client.ErrKeyExists):
+		code = codeUsage
+	case errors.As(err, &signerErr):
+		code = codeRemoteSignerFailed
+		details["signer_endpoint"] = signerErr.Endpoint
+		if signerErr.StatusCode != 0 {
+			details["status_code"] = signerErr.StatusCode
+		}
+	case errors.Is(err, client.ErrICANotRegistered):
+		code = codeICANotRegistered
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codeTimeout
+	}
+	var coded *codedError
+	if errors.As(err, &coded) {
+		if coded.code != "" {
+			code = coded.code
+		}
+		for k, v := range coded.details {
+			details[k] = v
+		}
+	}
+	return code, details
+}
+
+// reportError prints err as a JSON envelope on stdout (json output) or as text
+// on stderr, and returns the exit code for its class.
+func reportError(err error, jsonOutput bool) int {
+	code, details := classifyError(err)
+	if jsonOutput {
+		_ = writeJSON(map[string]any{
+			"status":  "error",
+			"code":    code,
+			"message": err.Error(),
+			"details": details,
+		})
+	} else {
+		fmt.Fprintln(os.Stderr, err.Error())
+		// Keep the envelope details (e.g. a balance shortfall) in text mode.
+		keys := make([]string, 0, len(details))
+		for k := range details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", k, details[k])
+		}
+	}
+	exitCode, ok := exitCodes[code]
+	if !ok {
+		exitCode = exitCodes[codeInternal]
+	}
+	return exitCode
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+
+	"lumera-ica-client/client"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code errorCode
+		exit int
+	}{
+		{"config", &client.ConfigError{Field: "lumera.chain_id", Reason: "is required"}, codeConfigInvalid, 3},
+		{"key not found", client.ErrKeyNotFound, codeKeyNotFound, 4},
+		{"key type mismatch", &client.KeyTypeMismatchError{KeyName: "k", Expected: "evm", Actual: "cosmos"}, codeKeyTypeMismatch, 5},
+		{"ica not registered", client.ErrICANotRegistered, codeICANotRegistered, 6},
+		{"channel closed", &client.ChannelClosedError{PortID: "icacontroller-x", ChannelID: "channel-1"}, codeICAChannelClosed, 7},
+		{"insufficient balance", &client.InsufficientBalanceError{
+			ICAAddress: "lumera1ica", Denom: "ulume", Required: sdkmath.NewInt(10), Available: sdkmath.NewInt(4),
+		}, codeInsufficientBalance, 8},
+		{"ack pending", &client.AckPendingError{
+			Ref: &client.PacketRef{TxHash: "AB", Port: "icacontroller-x", Channel: "channel-1", Sequence: 3}, Waited: time.Minute,
+		}, codeAckTimeout, 9},
+		{"ack error", &client.AckError{Code: 5, Log: "out of gas"}, codeAckError, 10},
+		{"packet timeout", &client.PacketTimeoutError{
+			Ref: &client.PacketRef{TxHash: "AB", Port: "icacontroller-x", Channel: "channel-1", Sequence: 3},
+		}, codePacketTimeout, 11},
+		{"deadline", context.DeadlineExceeded, codeTimeout, 14},
+		{"remote signer", &client.RemoteSignerError{Endpoint: "https://signer", Op: "sign", StatusCode: 500, Err: errors.New("boom")}, codeRemoteSignerFailed, 16},
+		{"wrong passphrase", &client.KeyringUnlockError{Dir: "/kr", Source: "controller.keyring_passphrase_env", Attempts: 1, Err: client.ErrWrongPassphrase}, codeWrongPassphrase, 17},
+		{"passphrase required", &client.KeyringUnlockError{Dir: "/kr", Err: client.ErrPassphraseRequired}, codeConfigInvalid, 3},
+		{"explicit code", withCode(codeUsage, errors.New("bad flag"), nil), codeUsage, 2},
+		{"unclassified", io.ErrUnexpectedEOF, codeInternal, 1},
+	}
+	for _, tc := range cases {
+		for _, wrapped := range []bool{false, true} {
+			err := tc.err
+			name := tc.name
+			if wrapped {
+				err = fmt.Errorf("upload: %w", err)
+				name += "/wrapped"
+			}
+			t.Run(name, func(t *testing.T) {
+				code, _ := classifyError(err)
+				if code != tc.code {
+					t.Fatalf("code = %s, want %s", code, tc.code)
+				}
+				if exit := exitCodes[code]; exit != tc.exit {
+					t.Fatalf("exit code = %d, want %d", exit, tc.exit)
+				}
+			})
+		}
+	}
+}
+
+func TestClassifyErrorDetails(t *testing.T) {
+	err := fmt.Errorf("upload: %w", &client.InsufficientBalanceError{
+		ICAAddress: "lumera1ica", Denom: "ulume", Required: sdkmath.NewInt(10), Available: sdkmath.NewInt(4),
+	})
+	_, details := classifyError(withDetails(err, map[string]any{"file": "/tmp/f"}))
+	want := map[string]any{
+		"ica_address": "lumera1ica",
+		"denom":       "ulume",
+		"required":    "10",
+		"available":   "4",
+		"shortfall":   "6",
+		"file":        "/tmp/f",
+	}
+	for k, v := range want {
+		if details[k] != v {
+			t.Errorf("details[%q] = %v, want %v", k, details[k], v)
+		}
+	}
+}
+
+func TestExitCodesUnique(t *testing.T) {
+	seen := map[int]errorCode{}
+	for code, exit := range exitCodes {
+		if other, ok := seen[exit]; ok {
+			t.Errorf("exit code %d used by %s and %s", exit, code, other)
+		}
+		seen[exit] = code
+	}
+}
+
+func TestCobraUsageErrors(t *testing.T) {
+	cases := [][]string{
+		{"upload", "a", "b"},
+		{"ica", "bogus"},
+		{"bogus"},
+		{"action", "status"},
+		{"upload", "--no-such-flag"},
+	}
+	for _, args := range cases {
+		t.Run(fmt.Sprint(args), func(t *testing.T) {
+			cmd := newRootCmd(&app{})
+			cmd.SetArgs(append(args, "--config", "/nonexistent/config.toml"))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			err := cmd.Execute()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code, _ := classifyError(err); code != codeUsage {
+				t.Fatalf("code = %s (%v), want %s", code, err, codeUsage)
+			}
+		})
+	}
+}
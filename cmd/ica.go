@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+// newICACmd groups interchain account management subcommands.
+func newICACmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ica",
+		Short: "Interchain account management commands",
+	}
+	cmd.AddCommand(newICARegisterCmd(app))
+	cmd.AddCommand(newICAReopenCmd(app))
+	cmd.AddCommand(newICAInfoCmd(app))
+	cmd.AddCommand(newICAFundCmd(app))
+	cmd.AddCommand(newICAWaitAckCmd(app))
+	return cmd
+}
+
+// newICARegisterCmd registers the ICA explicitly and waits for its channel to open.
+func newICARegisterCmd(app *app) *cobra.Command {
+	var ordering string
+	var version string
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Register the interchain account and wait for the channel to open",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			order, err := client.ParseOrdering(ordering)
+			if err != nil {
+				return err
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			// Initialize cascade client + controller helper.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			// Register only when the owner has no ICA channel yet. A handshake in
+			// progress is waited for; a closed channel is left to `ica reopen`.
+			info, err := controller.Info(ctx)
+			if err != nil {
+				return err
+			}
+			alreadyRegistered := info.Channel != nil
+			handshake := false
+			switch {
+			case info.Channel == nil:
+				info, err = controller.RegisterICA(ctx, client.RegisterOptions{Ordering: order, Version: version})
+			case info.Channel.State == channeltypes.CLOSED:
+				err = &client.ChannelClosedError{PortID: info.Channel.PortID, ChannelID: info.Channel.ChannelID}
+			case info.Channel.State != channeltypes.OPEN || info.ICAAddress == "":
+				// INIT or TRYOPEN: a registration is already under way.
+				handshake = true
+				info, err = controller.WaitForOpenChannel(ctx)
+			}
+			if err != nil {
+				return err
+			}
+			payload := icaInfoJSON(info)
+			payload["already_registered"] = alreadyRegistered
+			if handshake {
+				payload["awaited_handshake"] = true
+			}
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&ordering, "ordering", "ordered", "Channel ordering: ordered or unordered")
+	cmd.Flags().StringVar(&version, "version", "", "ICS-27 version metadata JSON (default: metadata for connection_id/counterparty_connection_id)")
+	return cmd
+}
+
+// newICAReopenCmd re-registers on the same port after the ICA channel closed
+// (e.g. an ordered-channel packet timeout) and waits for the new channel to open.
+func newICAReopenCmd(app *app) *cobra.Command {
+	var ordering string
+	var version string
+	cmd := &cobra.Command{
+		Use:   "reopen",
+		Short: "Open a new channel for the ICA after its channel was closed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := client.RegisterOptions{Version: version}
+			if cmd.Flags().Changed("ordering") {
+				order, err := client.ParseOrdering(ordering)
+				if err != nil {
+					return err
+				}
+				opts.Ordering = order
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			// Initialize cascade client + controller helper.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			closed, err := controller.ICAChannel(ctx)
+			if err != nil {
+				return err
+			}
+			info, err := controller.ReopenICA(ctx, opts)
+			if err != nil {
+				return err
+			}
+			payload := icaInfoJSON(info)
+			payload["closed_channel_id"] = closed.ChannelID
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&ordering, "ordering", "", "Channel ordering: ordered or unordered (default: ordering of the closed channel)")
+	cmd.Flags().StringVar(&version, "version", "", "ICS-27 version metadata JSON (default: version of the closed channel)")
+	return cmd
+}
+
+// newICAInfoCmd reports the ICA address, IBC identifiers, channel state and Lumera balances.
+func newICAInfoCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show interchain account, channel and balance details",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			// Initialize cascade client + controller helper.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			info, err := controller.Info(ctx)
+			if err != nil {
+				return err
+			}
+			return writeJSON(icaInfoJSON(info))
+		},
+	}
+	return cmd
+}
+
+// newICAFundCmd tops up the ICA on Lumera with an ICS-20 transfer signed by the controller key.
+func newICAFundCmd(app *app) *cobra.Command {
+	var amount string
+	var channel string
+	cmd := &cobra.Command{
+		Use:   "fund",
+		Short: "Fund the ICA on Lumera via ICS-20 transfer",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Parse the transfer amount before touching the keyring.
+			coin, err := sdk.ParseCoinNormalized(strings.TrimSpace(amount))
+			if err != nil {
+				return err
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			// Initialize cascade client + controller helper.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			// Transfer from the controller owner and wait for the funds to arrive.
+			res, err := controller.FundICA(ctx, coin, channel)
+			if err != nil {
+				return err
+			}
+			payload := fundResultJSON(res)
+			payload["status"] = "ok"
+			payload["amount"] = coin.String()
+			payload["ica_owner_address"] = controller.OwnerAddress()
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&amount, "amount", "", "Amount to transfer, e.g. 5000000ibc/<hash>")
+	cmd.Flags().StringVar(&channel, "channel", "", "Controller-side transfer channel (default: open transfer channel on controller.connection_id)")
+	_ = cmd.MarkFlagRequired("amount")
+	return cmd
+}
+
+// newICAWaitAckCmd finds the ICA packet sent by a controller tx, waits for its
+// ack (or timeout) on Lumera and prints the decoded message responses.
+func newICAWaitAckCmd(app *app) *cobra.Command {
+	var timeouts icaTimeoutFlags
+	cmd := &cobra.Command{
+		Use:   "wait-ack <controller-tx-hash>",
+		Short: "Wait for and decode the ack of an ICA packet by controller tx hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := timeouts.apply(cmd, cfg); err != nil {
+				return err
+			}
+			ctx, cancel := icaCommandContext(cmd, cfg)
+			defer cancel()
+
+			// Initialize cascade client + controller helper.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			ref, err := controller.PacketRefFromTx(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			ack, err := controller.WaitICAAck(ctx, ref)
+			if err != nil {
+				return err
+			}
+			if err := ack.Result(); err != nil {
+				return withDetails(err, icaAckJSON(ack))
+			}
+			payload := icaAckJSON(ack)
+			payload["status"] = "ok"
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().DurationVar(&timeouts.ackWaitTimeout, "ack-wait-timeout", 0, "How long to wait for the ICA ack before reporting it pending (overrides controller.ack_wait_timeout)")
+	return cmd
+}
+
+// icaAckJSON renders a decoded ICA ack; "ack" is result, error or timeout.
+// For error and timeout acks it is used as the error envelope details.
+func icaAckJSON(ack *client.ICAAck) map[string]any {
+	payload := map[string]any{
+		"tx_hash":         ack.Ref.TxHash,
+		"packet_port":     ack.Ref.Port,
+		"packet_channel":  ack.Ref.Channel,
+		"packet_sequence": ack.Ref.Sequence,
+		"ack":             "result",
+	}
+	switch {
+	case ack.TimedOut:
+		payload["ack"] = "timeout"
+		return payload
+	case ack.Err != nil:
+		payload["ack"] = "error"
+		return payload
+	}
+	responses := make([]map[string]any, len(ack.Responses))
+	for i, resp := range ack.Responses {
+		responses[i] = map[string]any{
+			"type_url":  resp.TypeURL,
+			"action_id": resp.ActionID,
+			"status":    resp.Status,
+		}
+	}
+	payload["responses"] = responses
+	payload["action_ids"] = ack.ActionIDs()
+	return payload
+}
+
+// icaInfoJSON renders ICA details; channel fields are empty when no channel exists.
+func icaInfoJSON(info *client.ICAInfo) map[string]any {
+	payload := map[string]any{
+		"status":                  "ok",
+		"ica_address":             info.ICAAddress,
+		"ica_owner_address":       info.OwnerAddress,
+		"connection_id":           info.ConnectionID,
+		"host_connection_id":      info.HostConnectionID,
+		"port_id":                 "",
+		"channel_id":              "",
+		"counterparty_port_id":    "",
+		"counterparty_channel_id": "",
+		"channel_state":           "",
+		"ordering":                "",
+		"version":                 "",
+		"balances":                info.Balances.String(),
+	}
+	if ch := info.Channel; ch != nil {
+		payload["port_id"] = ch.PortID
+		payload["channel_id"] = ch.ChannelID
+		payload["counterparty_port_id"] = ch.CounterpartyPortID
+		payload["counterparty_channel_id"] = ch.CounterpartyChannelID
+		payload["channel_state"] = ch.State.String()
+		payload["ordering"] = ch.Ordering.String()
+		payload["version"] = ch.Version
+	}
+	return payload
+}
+
+// fundResultJSON renders an ICS-20 top-up result for command output.
+func fundResultJSON(res *client.FundResult) map[string]any {
+	return map[string]any{
+		"tx_hash":         res.TxHash,
+		"channel":         res.Channel,
+		"packet_sequence": res.Sequence,
+		"ica_address":     res.ICAAddress,
+		"balance_before":  res.BalanceBefore.String(),
+		"balance_after":   res.BalanceAfter.String(),
+	}
+}
@@ -0,0 +1,427 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"lumera-ica-client/client"
+)
+
+// keysTarget selects the keyring the keys subcommands operate on.
+type keysTarget struct {
+	app        *app
+	appKeyring bool
+}
+
+// newKeysCmd groups keyring management subcommands. They use the keyring the
+// other commands sign with: same backend, dir, passphrase source and app name.
+func newKeysCmd(app *app) *cobra.Command {
+	t := &keysTarget{app: app}
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage keys in the controller keyring",
+	}
+	cmd.PersistentFlags().BoolVar(&t.appKeyring, "app-keyring", false, "Use the [app_key] keyring instead of the controller keyring")
+	cmd.AddCommand(newKeysListCmd(t))
+	cmd.AddCommand(newKeysShowCmd(t))
+	cmd.AddCommand(newKeysAddCmd(t))
+	cmd.AddCommand(newKeysImportCmd(t))
+	cmd.AddCommand(newKeysExportCmd(t))
+	cmd.AddCommand(newKeysDeleteCmd(t))
+	return cmd
+}
+
+// open loads the config and opens the selected keyring. Commands that need
+// private key material (writing or exporting keys) set local, which the
+// remote signer cannot provide.
+func (t *keysTarget) open(local bool) (*client.Config, keyring.Keyring, error) {
+	cfg, err := t.app.loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.appKeyring {
+		if !cfg.AppKey.Enabled() || !cfg.AppKey.SeparateKeyring() {
+			return nil, nil, withCode(codeUsage, fmt.Errorf("--app-keyring needs [app_key] with its own keyring_backend"), nil)
+		}
+		kr, err := client.OpenAppKeyring(cfg, nil)
+		return cfg, kr, err
+	}
+	if local && cfg.Controller.RemoteSigner() {
+		return nil, nil, withCode(codeUsage, fmt.Errorf("controller.signer is remote; manage its keys on the signer %s", cfg.Controller.SignerEndpoint), nil)
+	}
+	kr, err := client.OpenControllerKeyring(cfg.Controller)
+	return cfg, kr, err
+}
+
+// defaultKeyType is the configured key type of the selected keyring's key.
+func (t *keysTarget) defaultKeyType(cfg *client.Config) string {
+	if t.appKeyring {
+		return cfg.AppKey.KeyType
+	}
+	return cfg.Controller.KeyType
+}
+
+// keyJSON renders a key with its controller-HRP and Lumera addresses and the
+// base64 pubkey as used for app_pubkey.
+func keyJSON(key client.KeyringKey) map[string]any {
+	return map[string]any{
+		"name":           key.Name,
+		"key_type":       key.KeyType,
+		"algo":           key.Algo,
+		"address":        key.Address,
+		"lumera_address": key.LumeraAddress,
+		"app_pubkey":     base64.StdEncoding.EncodeToString(key.PubKey),
+	}
+}
+
+// keyRoles lists the config fields that name key.
+func keyRoles(cfg *client.Config, name string) []string {
+	roles := []string{}
+	if cfg.Controller.KeyName == name {
+		roles = append(roles, "controller")
+	}
+	if cfg.Lumera.KeyName == name {
+		roles = append(roles, "lumera")
+	}
+	if cfg.AppKeyName() == name {
+		roles = append(roles, "app")
+	}
+	return roles
+}
+
+func newKeysListCmd(t *keysTarget) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List keys with their addresses and configured roles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, kr, err := t.open(false)
+			if err != nil {
+				return err
+			}
+			keys, err := client.ListKeys(kr, cfg.Controller.AccountHRP)
+			if err != nil {
+				return err
+			}
+			out := make([]map[string]any, 0, len(keys))
+			for _, key := range keys {
+				entry := keyJSON(key)
+				entry["roles"] = keyRoles(cfg, key.Name)
+				out = append(out, entry)
+			}
+			return writeJSON(map[string]any{"status": "ok", "keys": out})
+		},
+	}
+}
+
+func newKeysShowCmd(t *keysTarget) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show a key's addresses and app pubkey (default: the configured key)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, kr, err := t.open(false)
+			if err != nil {
+				return err
+			}
+			name := cfg.Controller.KeyName
+			if t.appKeyring {
+				name = cfg.AppKey.KeyName
+			}
+			if len(args) == 1 {
+				name = args[0]
+			}
+			key, err := client.ShowKey(kr, name, cfg.Controller.AccountHRP)
+			if err != nil {
+				return err
+			}
+			payload := keyJSON(key)
+			payload["status"] = "ok"
+			payload["roles"] = keyRoles(cfg, key.Name)
+			return writeJSON(payload)
+		},
+	}
+}
+
+func newKeysAddCmd(t *keysTarget) *cobra.Command {
+	var (
+		keyType      string
+		fromMnemonic bool
+		mnemonicFile string
+	)
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create a new key, or recover one from a mnemonic with --recover",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if mnemonicFile != "" && !fromMnemonic {
+				return withCode(codeUsage, fmt.Errorf("--mnemonic-file requires --recover"), nil)
+			}
+			if fromMnemonic && mnemonicFile == "" && t.app.passphraseStdin {
+				return withCode(codeUsage, fmt.Errorf("--passphrase-stdin uses stdin; pass the mnemonic with --mnemonic-file"), nil)
+			}
+			cfg, kr, err := t.open(true)
+			if err != nil {
+				return err
+			}
+			if keyType == "" {
+				keyType = t.defaultKeyType(cfg)
+			}
+			name := args[0]
+			var mnemonic string
+			if fromMnemonic {
+				phrase, err := readMnemonic(cmd, mnemonicFile)
+				if err != nil {
+					return err
+				}
+				if err := client.RecoverKey(kr, name, phrase, keyType); err != nil {
+					return err
+				}
+			} else if mnemonic, err = client.CreateKey(kr, name, keyType); err != nil {
+				return err
+			}
+			key, err := client.ShowKey(kr, name, cfg.Controller.AccountHRP)
+			if err != nil {
+				return err
+			}
+			payload := keyJSON(key)
+			payload["status"] = "ok"
+			if mnemonic != "" {
+				// Shown once; the keyring does not keep it.
+				payload["mnemonic"] = mnemonic
+			}
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&keyType, "key-type", "", "Key type: cosmos or evm (default: the configured key_type)")
+	cmd.Flags().BoolVar(&fromMnemonic, "recover", false, "Recover the key from a mnemonic instead of generating one")
+	cmd.Flags().StringVar(&mnemonicFile, "mnemonic-file", "", "File holding the mnemonic for --recover (default: read from stdin)")
+	return cmd
+}
+
+func newKeysImportCmd(t *keysTarget) *cobra.Command {
+	var (
+		keyType        string
+		passphraseFile string
+		fromHex        bool
+	)
+	cmd := &cobra.Command{
+		Use:   "import <name> <file>",
+		Short: "Import an ASCII-armored (cosmos) or hex (--hex) private key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[1])
+			if err != nil {
+				return withCode(codeUsage, fmt.Errorf("read key file: %w", err), nil)
+			}
+			cfg, kr, err := t.open(true)
+			if err != nil {
+				return err
+			}
+			if keyType == "" {
+				keyType = t.defaultKeyType(cfg)
+			}
+			if fromHex {
+				err = client.ImportKeyHex(kr, args[0], string(data), keyType)
+			} else {
+				passphrase, perr := armorPassphrase(cmd, passphraseFile, false)
+				if perr != nil {
+					return perr
+				}
+				err = client.ImportArmoredKey(kr, args[0], string(data), passphrase, keyType)
+			}
+			if err != nil {
+				return err
+			}
+			key, err := client.ShowKey(kr, args[0], cfg.Controller.AccountHRP)
+			if err != nil {
+				return err
+			}
+			payload := keyJSON(key)
+			payload["status"] = "ok"
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&keyType, "key-type", "", "Key type: cosmos or evm (default: the configured key_type); checked against armored keys")
+	cmd.Flags().StringVar(&passphraseFile, "armor-passphrase-file", "", "File holding the armor passphrase (default: prompt)")
+	cmd.Flags().BoolVar(&fromHex, "hex", false, "The file holds an unencrypted hex private key (needed for evm keys)")
+	return cmd
+}
+
+func newKeysExportCmd(t *keysTarget) *cobra.Command {
+	var (
+		passphraseFile string
+		outFile        string
+		unarmoredHex   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a private key, ASCII-armored and passphrase-encrypted (cosmos) or as hex",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if unarmoredHex && passphraseFile != "" {
+				return withCode(codeUsage, fmt.Errorf("--armor-passphrase-file does not apply to --unarmored-hex"), nil)
+			}
+			_, kr, err := t.open(true)
+			if err != nil {
+				return err
+			}
+			var exported string
+			field := "armor"
+			if unarmoredHex {
+				field = "hex"
+				exported, err = client.ExportKeyHex(kr, args[0])
+			} else {
+				passphrase, perr := armorPassphrase(cmd, passphraseFile, true)
+				if perr != nil {
+					return perr
+				}
+				exported, err = client.ExportArmoredKey(kr, args[0], passphrase)
+			}
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"status": "ok", "name": args[0]}
+			if outFile == "" {
+				payload[field] = exported
+				return writeJSON(payload)
+			}
+			f, err := os.OpenFile(outFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return fmt.Errorf("write %s: %w", outFile, err)
+			}
+			_, err = f.WriteString(exported + "\n")
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return fmt.Errorf("write %s: %w", outFile, err)
+			}
+			payload["file"] = outFile
+			return writeJSON(payload)
+		},
+	}
+	cmd.Flags().StringVar(&passphraseFile, "armor-passphrase-file", "", "File holding the armor passphrase (default: prompt)")
+	cmd.Flags().StringVar(&outFile, "out", "", "Write the key to this new file (mode 0600) instead of stdout")
+	cmd.Flags().BoolVar(&unarmoredHex, "unarmored-hex", false, "Export the unencrypted private key as hex (needed for evm keys)")
+	return cmd
+}
+
+func newKeysDeleteCmd(t *keysTarget) *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a key from the keyring",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, kr, err := t.open(true)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			if _, err := client.ShowKey(kr, name, cfg.Controller.AccountHRP); err != nil {
+				return err
+			}
+			roles := keyRoles(cfg, name)
+			if !yes {
+				if !term.IsTerminal(int(os.Stdin.Fd())) {
+					return withCode(codeUsage, fmt.Errorf("refusing to delete %q without --yes", name), nil)
+				}
+				prompt := fmt.Sprintf("Delete key %q", name)
+				if len(roles) > 0 {
+					prompt += fmt.Sprintf(" (configured as %s)", strings.Join(roles, ", "))
+				}
+				fmt.Fprint(cmd.ErrOrStderr(), prompt+"? [y/N]: ")
+				answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+					return withCode(codeUsage, fmt.Errorf("delete of %q aborted", name), nil)
+				}
+			}
+			if err := client.DeleteKey(kr, name); err != nil {
+				return err
+			}
+			return writeJSON(map[string]any{"status": "ok", "name": name, "deleted": true, "roles": roles})
+		},
+	}
+	cmd.Flags().BoolVar(&yes, "yes", false, "Delete without asking for confirmation")
+	return cmd
+}
+
+// readMnemonic reads a mnemonic from path, or from stdin (prompting when it is
+// a terminal).
+func readMnemonic(cmd *cobra.Command, path string) (string, error) {
+	var data []byte
+	var err error
+	if path != "" {
+		data, err = os.ReadFile(path)
+	} else {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Fprint(cmd.ErrOrStderr(), "Enter mnemonic: ")
+		}
+		var line string
+		line, err = bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		data = []byte(line)
+	}
+	if err != nil {
+		return "", withCode(codeUsage, fmt.Errorf("read mnemonic: %w", err), nil)
+	}
+	mnemonic := strings.TrimSpace(string(data))
+	if mnemonic == "" {
+		return "", withCode(codeUsage, fmt.Errorf("mnemonic is empty"), nil)
+	}
+	return mnemonic, nil
+}
+
+// armorPassphrase reads the armor passphrase from path, or prompts for it on
+// a terminal; confirm asks twice (used when encrypting).
+func armorPassphrase(cmd *cobra.Command, path string, confirm bool) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", withCode(codeUsage, fmt.Errorf("read armor passphrase file: %w", err), nil)
+		}
+		pass := strings.TrimSpace(string(data))
+		if pass == "" {
+			return "", withCode(codeUsage, fmt.Errorf("armor passphrase file is empty"), nil)
+		}
+		return pass, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", withCode(codeUsage, fmt.Errorf("stdin is not a terminal; pass --armor-passphrase-file"), nil)
+	}
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(cmd.ErrOrStderr(), prompt)
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(cmd.ErrOrStderr())
+		return string(b), err
+	}
+	pass, err := read("Armor passphrase: ")
+	if err != nil {
+		return "", fmt.Errorf("read armor passphrase: %w", err)
+	}
+	if pass == "" {
+		return "", withCode(codeUsage, fmt.Errorf("armor passphrase is empty"), nil)
+	}
+	if confirm {
+		again, err := read("Repeat armor passphrase: ")
+		if err != nil {
+			return "", fmt.Errorf("read armor passphrase: %w", err)
+		}
+		if again != pass {
+			return "", withCode(codeUsage, fmt.Errorf("armor passphrases do not match"), nil)
+		}
+	}
+	return pass, nil
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+// newProfilesCmd groups config profile subcommands.
+func newProfilesCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Config profile commands",
+	}
+	cmd.AddCommand(newProfilesListCmd(app))
+	cmd.AddCommand(newProfilesCheckCmd(app))
+	return cmd
+}
+
+// newProfilesListCmd lists the [profiles.<name>] blocks with their resolved
+// controller settings, and whether each profile passes validation.
+func newProfilesListCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List config profiles and whether each one validates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := filepath.Clean(strings.TrimSpace(app.configPath))
+			names, err := client.ReadProfileNames(path)
+			if err != nil {
+				return withDetails(err, map[string]any{"config": path})
+			}
+			profiles := make([]map[string]any, 0, len(names))
+			for _, name := range names {
+				entry := map[string]any{"name": name, "journal": client.ProfileJournalPath(path, name)}
+				p := *app
+				p.profile = name
+				cfg, _, err := p.resolveConfig(true)
+				if err != nil {
+					entry["valid"] = false
+					entry["error"] = err.Error()
+				} else {
+					entry["valid"] = true
+					entry["controller_chain_id"] = cfg.Controller.ChainID
+					entry["lumera_chain_id"] = cfg.Lumera.ChainID
+					entry["connection_id"] = cfg.Controller.ConnectionID
+					entry["key_name"] = cfg.Controller.KeyName
+				}
+				profiles = append(profiles, entry)
+			}
+			return writeJSON(map[string]any{
+				"status":   "ok",
+				"config":   path,
+				"profiles": profiles,
+			})
+		},
+	}
+	return cmd
+}
+
+// newProfilesCheckCmd runs the doctor checks for every profile, or only for
+// --profile when it is set, and exits non-zero when any profile fails.
+func newProfilesCheckCmd(app *app) *cobra.Command {
+	var checkTimeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Run doctor checks for each config profile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if checkTimeout <= 0 {
+				return withCode(codeUsage, fmt.Errorf("--check-timeout must be positive"), nil)
+			}
+			path := filepath.Clean(strings.TrimSpace(app.configPath))
+			names := []string{app.profile}
+			if app.profile == "" {
+				var err error
+				names, err = client.ReadProfileNames(path)
+				if err != nil {
+					return withDetails(err, map[string]any{"config": path})
+				}
+				if len(names) == 0 {
+					return withCode(codeConfigInvalid, fmt.Errorf("%s defines no [profiles.<name>] blocks; use doctor instead", path), map[string]any{"config": path})
+				}
+			}
+			ctx, cancel := commandContext(cmd)
+			defer cancel()
+
+			reports := make([]map[string]any, 0, len(names))
+			var failed []string
+			for _, name := range names {
+				p := *app
+				p.profile = name
+				d := &doctor{app: &p, timeout: checkTimeout}
+				d.run(ctx)
+				report := d.report()
+				report["profile"] = name
+				reports = append(reports, report)
+				if len(d.failed()) > 0 {
+					failed = append(failed, name)
+				}
+			}
+			status := "ok"
+			if len(failed) > 0 {
+				status = "error"
+			}
+			if err := writeJSON(map[string]any{"status": status, "config": path, "profiles": reports}); err != nil {
+				return err
+			}
+			if len(failed) > 0 {
+				return withCode(codeChecksFailed, fmt.Errorf("%d of %d profiles failed checks: %s", len(failed), len(names), strings.Join(failed, ", ")),
+					map[string]any{"failed_profiles": failed})
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&checkTimeout, "check-timeout", defaultCheckTimeout, "Timeout for each network check")
+	return cmd
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const profilesTestConfig = `
+[lumera]
+chain_id = "lumera-testnet-2"
+grpc_endpoint = "localhost:9090"
+rpc_endpoint = "http://localhost:26657"
+key_name = "lumera"
+
+[controller]
+chain_id = "osmo-test-5"
+account_hrp = "osmo"
+grpc_endpoint = "localhost:9091"
+rpc_endpoint = "http://localhost:26658"
+key_name = "osmo-key"
+keyring_backend = "test"
+connection_id = "connection-1"
+
+[profiles.injective.controller]
+chain_id = "injective-888"
+account_hrp = "inj"
+connection_id = "connection-2"
+`
+
+func TestAppProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte(profilesTestConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{configPath: path, profile: "injective"}
+	cfg, sources, err := a.resolveConfig(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Controller.ChainID != "injective-888" || sources["controller.chain_id"] != "profile" {
+		t.Fatalf("chain_id = %q from %s, want injective-888 from profile", cfg.Controller.ChainID, sources["controller.chain_id"])
+	}
+	if cfg.Controller.KeyName != "osmo-key" || sources["controller.key_name"] != "file" {
+		t.Fatalf("key_name = %q from %s, want the inherited osmo-key", cfg.Controller.KeyName, sources["controller.key_name"])
+	}
+	if got, want := a.journalPath(), filepath.Join(dir, "journal.injective.db"); got != want {
+		t.Fatalf("journal path = %q, want %q", got, want)
+	}
+	if got, want := (&app{configPath: path}).journalPath(), filepath.Join(dir, "journal.db"); got != want {
+		t.Fatalf("journal path without a profile = %q, want %q", got, want)
+	}
+
+	a.profile = "nope"
+	_, _, err = a.resolveConfig(false)
+	code, details := classifyError(err)
+	if code != codeConfigInvalid || details["profile"] != "nope" || details["config"] != path {
+		t.Fatalf("undefined profile: code = %s, details = %v", code, details)
+	}
+}
@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LumeraProtocol/sdk-go/blockchain"
+	"github.com/LumeraProtocol/sdk-go/cascade"
+	"github.com/LumeraProtocol/sdk-go/types"
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+// newResumeCmd finishes journaled uploads based on the on-chain action state.
+// It emits one JSON line per record followed by a summary line.
+func newResumeCmd(app *app) *cobra.Command {
+	var all bool
+	var actionID string
+	var approve bool
+	var timeouts icaTimeoutFlags
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Finish half-done ICA uploads recorded in the local journal",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actionID = strings.TrimSpace(actionID)
+			if all == (actionID != "") {
+				return withCode(codeUsage, fmt.Errorf("exactly one of --all or --action-id is required"), nil)
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := timeouts.apply(cmd, cfg); err != nil {
+				return err
+			}
+			ctx, cancel := icaCommandContext(cmd, cfg)
+			defer cancel()
+
+			journal, err := client.OpenJournal(app.journalPath())
+			if err != nil {
+				return err
+			}
+			defer journal.Close()
+			// Select the records to resume.
+			var recs []*client.UploadRecord
+			if all {
+				listed, err := journal.List()
+				if err != nil {
+					return err
+				}
+				for _, rec := range listed {
+					if !rec.Finished() {
+						recs = append(recs, rec)
+					}
+				}
+			} else {
+				rec, err := journal.FindByActionID(actionID)
+				if err != nil {
+					return err
+				}
+				if rec == nil {
+					return withCode(codeUsage, fmt.Errorf("action %s is not in the journal %s", actionID, app.journalPath()),
+						map[string]any{"action_id": actionID, "journal": app.journalPath()})
+				}
+				recs = append(recs, rec)
+			}
+
+			// Initialize cascade client, controller and Lumera query client.
+			cascClient, err := client.NewCascadeClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer cascClient.Cascade.Close()
+
+			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+			if err != nil {
+				return err
+			}
+			defer controller.Close()
+			// Records are only resumed with the controller key that registered them.
+			icaAddr, err := controller.ICAAddress(ctx)
+			if err != nil {
+				return err
+			}
+
+			bc, err := client.NewLumeraClient(ctx, cfg, cascClient.Keyring, cfg.Controller.KeyName)
+			if err != nil {
+				return err
+			}
+			defer bc.Close()
+
+			r := &resumer{
+				cascade:    cascClient.Cascade,
+				controller: controller,
+				bc:         bc,
+				journal:    journal,
+				icaAddr:    icaAddr,
+				approve:    approve,
+			}
+			failed, pending := 0, 0
+			var firstErr error
+			for _, rec := range recs {
+				line, err := r.resume(ctx, rec)
+				switch line["status"] {
+				case "ok":
+				case "pending":
+					pending++
+				default:
+					failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+				}
+				if err := writeJSONLine(line); err != nil {
+					return err
+				}
+			}
+			status := "ok"
+			switch {
+			case failed > 0:
+				status = "error"
+			case pending > 0:
+				status = "pending"
+			}
+			if err := writeJSONLine(map[string]any{"status": status, "total": len(recs), "failed": failed, "pending": pending}); err != nil {
+				return err
+			}
+			if failed > 0 {
+				// The first failure decides the error code.
+				return withDetails(fmt.Errorf("%d of %d records could not be resumed: %w", failed, len(recs), firstErr),
+					map[string]any{"total": len(recs), "failed": failed})
+			}
+			if pending > 0 {
+				return withCode(codeAckTimeout, fmt.Errorf("%d of %d records are still waiting for an ica ack", pending, len(recs)),
+					map[string]any{"total": len(recs), "pending": pending})
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Resume every unfinished journal record")
+	cmd.Flags().StringVar(&actionID, "action-id", "", "Resume the journal record for this action ID")
+	cmd.Flags().BoolVar(&approve, "approve", false, "Send MsgApproveAction via ICA for actions in DONE state")
+	timeouts.register(cmd)
+	return cmd
+}
+
+// resumer holds the clients needed to move a journal record forward.
+type resumer struct {
+	cascade    *cascade.Client
+	controller *client.Controller
+	bc         *blockchain.Client
+	journal    *client.Journal
+	icaAddr    string
+	approve    bool
+}
+
+// resume takes the next step for one record and returns its JSON line and
+// the step's error.
+func (r *resumer) resume(ctx context.Context, rec *client.UploadRecord) (map[string]any, error) {
+	line := map[string]any{
+		"status":    "ok",
+		"file":      rec.FilePath,
+		"file_hash": rec.FileHash,
+	}
+	action, step, err := r.advance(ctx, rec)
+	line["action_id"] = rec.ActionID
+	line["step"] = step
+	if rec.TaskID != "" {
+		line["task_id"] = rec.TaskID
+	}
+	if action != nil {
+		line["state"] = action.State
+	}
+	if rec.Outcome != "" {
+		line["outcome"] = rec.Outcome
+	}
+	var pending *client.AckPendingError
+	switch {
+	case errors.As(err, &pending):
+		line["status"] = "pending"
+		line["tx_hash"] = pending.Ref.TxHash
+		line["packet_sequence"] = pending.Ref.Sequence
+		line["error"] = err.Error()
+	case err != nil:
+		line["status"] = "error"
+		line["error"] = err.Error()
+	}
+	return line, err
+}
+
+// advance resolves the action state and performs the matching step:
+// upload bytes for PENDING, approve DONE when requested, and record terminal states.
+func (r *resumer) advance(ctx context.Context, rec *client.UploadRecord) (*types.Action, string, error) {
+	if rec.ICAAddress != "" && rec.ICAAddress != r.icaAddr {
+		return nil, "none", withCode(codeUsage,
+			fmt.Errorf("record belongs to ICA %s, not %s; resume it with the profile that uploaded it", rec.ICAAddress, r.icaAddr),
+			map[string]any{"ica_address": rec.ICAAddress})
+	}
+	if rec.ActionID == "" {
+		if rec.Step() == client.UploadStepSubmitted {
+			ref, err := r.controller.WaitForPacket(ctx, rec.TxHash)
+			if registrationLost(err) {
+				// The registration never reached Lumera; rerunning upload registers anew.
+				rec.Outcome = client.UploadOutcomeLost
+				return nil, "mark_lost", r.journal.Put(rec)
+			}
+			if err != nil {
+				return nil, "await_tx", err
+			}
+			rec.SetPacket(ref)
+			if err := r.journal.Put(rec); err != nil {
+				return nil, "await_tx", err
+			}
+		}
+		ref := rec.PacketRef()
+		if ref == nil {
+			return nil, "none", fmt.Errorf("registration was never broadcast; rerun upload for this file")
+		}
+		results, err := r.controller.AwaitRequestActions(ctx, ref)
+		if registrationLost(err) {
+			rec.Outcome = client.UploadOutcomeLost
+			return nil, "mark_lost", r.journal.Put(rec)
+		}
+		if err != nil {
+			return nil, "await_ack", err
+		}
+		if err := rec.SetActionID(results); err != nil {
+			return nil, "await_ack", err
+		}
+		if err := r.journal.Put(rec); err != nil {
+			return nil, "await_ack", err
+		}
+	}
+
+	action, err := r.bc.Action.GetAction(ctx, rec.ActionID)
+	if err != nil {
+		return nil, "query", err
+	}
+	switch action.State {
+	case types.ActionStatePending:
+		if rec.TaskID != "" {
+			// The bytes were handed to a supernode; wait for it to finalize.
+			return action, "wait_supernode", nil
+		}
+		hash, err := client.HashFile(rec.FilePath)
+		if err != nil {
+			return action, "upload", err
+		}
+		if hash != rec.FileHash {
+			return action, "upload", fmt.Errorf("file %s changed since registration", rec.FilePath)
+		}
+		taskID, err := r.cascade.UploadToSupernode(ctx, rec.ActionID, rec.FilePath, action.Creator)
+		if err != nil {
+			return action, "upload", err
+		}
+		rec.TaskID = taskID
+		return action, "upload", r.journal.Put(rec)
+	case types.ActionStateDone:
+		if !r.approve {
+			rec.Outcome = client.UploadOutcomeDone
+			return action, "none", r.journal.Put(rec)
+		}
+		msg, err := cascade.CreateApproveActionMessage(ctx, rec.ActionID, cascade.WithApproveCreator(action.Creator))
+		if err != nil {
+			return action, "approve", err
+		}
+		if _, err := r.controller.SendApproveAction(ctx, msg); err != nil {
+			return action, "approve", err
+		}
+		rec.Outcome = client.UploadOutcomeApproved
+		return action, "approve", r.journal.Put(rec)
+	case types.ActionStateApproved:
+		rec.Outcome = client.UploadOutcomeApproved
+		return action, "none", r.journal.Put(rec)
+	case types.ActionStateFailed:
+		rec.Outcome = client.UploadOutcomeFailed
+		return action, "none", r.journal.Put(rec)
+	case types.ActionStateExpired:
+		rec.Outcome = client.UploadOutcomeLost
+		return action, "mark_lost", r.journal.Put(rec)
+	default:
+		// PROCESSING: supernodes are working; nothing to do yet.
+		return action, "none", nil
+	}
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"lumera-ica-client/client"
+)
+
+const defaultSignerListen = "127.0.0.1:7755"
+
+// newSignerCmd groups remote signer subcommands.
+func newSignerCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Remote signer commands",
+	}
+	cmd.AddCommand(newSignerServeCmd(app))
+	return cmd
+}
+
+// newSignerServeCmd serves the controller keyring over the remote signing
+// protocol, as a local stand-in for a KMS/HSM-backed signer. It runs until
+// interrupted. The endpoint signs any bytes with the served keys, so it only
+// listens beyond loopback behind mutual TLS.
+func newSignerServeCmd(app *app) *cobra.Command {
+	var (
+		listen      string
+		tlsCert     string
+		tlsKey      string
+		tlsClientCA string
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the controller keyring as a remote signer (local stand-in for testing)",
+		Long: `Serve the controller keyring over the remote signing protocol.
+
+This is a local stand-in for a KMS/HSM-backed signer, meant for testing the
+remote signer setup. It signs any bytes it is sent with the keyring's keys, so
+it is not a production signer. A --listen address other than loopback is
+refused unless mutual TLS is enabled with --tls-cert, --tls-key and
+--tls-client-ca.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (tlsCert == "") != (tlsKey == "") {
+				return withCode(codeUsage, fmt.Errorf("--tls-cert and --tls-key must be set together"), nil)
+			}
+			if tlsClientCA != "" && tlsCert == "" {
+				return withCode(codeUsage, fmt.Errorf("--tls-client-ca requires --tls-cert and --tls-key"), nil)
+			}
+			loopback, err := isLoopbackListen(listen)
+			if err != nil {
+				return withCode(codeUsage, fmt.Errorf("--listen: %w", err), nil)
+			}
+			if !loopback && (tlsCert == "" || tlsClientCA == "") {
+				return withCode(codeUsage,
+					fmt.Errorf("--listen %s is not a loopback address; serving keys beyond loopback requires --tls-cert, --tls-key and --tls-client-ca", listen),
+					map[string]any{"listen": listen})
+			}
+			cfg, err := app.loadConfig()
+			if err != nil {
+				return err
+			}
+			// Always serve the local keyring, even when this config points at a remote signer.
+			kcfg := cfg.Controller
+			kcfg.Signer = client.SignerKeyring
+			if kcfg.KeyringBackend == "" {
+				return &client.ConfigError{Field: "controller.keyring_backend", Reason: "is required to serve the local keyring"}
+			}
+			kr, err := client.OpenControllerKeyring(kcfg)
+			if err != nil {
+				return err
+			}
+			signer := client.KeyringSigner{Keyring: kr}
+			keys, err := signer.Keys(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			server := &http.Server{Handler: client.NewSignerHandler(signer)}
+			scheme := "http"
+			if tlsCert != "" {
+				scheme = "https"
+				server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+				if tlsClientCA != "" {
+					pem, err := os.ReadFile(tlsClientCA)
+					if err != nil {
+						return fmt.Errorf("read --tls-client-ca: %w", err)
+					}
+					pool := x509.NewCertPool()
+					if !pool.AppendCertsFromPEM(pem) {
+						return fmt.Errorf("--tls-client-ca %s contains no PEM certificates", tlsClientCA)
+					}
+					server.TLSConfig.ClientCAs = pool
+					server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			ln, err := net.Listen("tcp", listen)
+			if err != nil {
+				return fmt.Errorf("listen on %s: %w", listen, err)
+			}
+			names := make([]string, 0, len(keys))
+			for _, key := range keys {
+				names = append(names, key.Name)
+			}
+			if err := writeJSON(map[string]any{
+				"status":   "ok",
+				"endpoint": scheme + "://" + ln.Addr().String(),
+				"keys":     names,
+			}); err != nil {
+				_ = ln.Close()
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				_ = server.Shutdown(context.Background())
+			}()
+			if scheme == "https" {
+				err = server.ServeTLS(ln, tlsCert, tlsKey)
+			} else {
+				err = server.Serve(ln)
+			}
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", defaultSignerListen, "Address to listen on")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "Server certificate (PEM); enables https")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "Server private key (PEM)")
+	cmd.Flags().StringVar(&tlsClientCA, "tls-client-ca", "", "CA bundle (PEM) that client certificates must chain to; enables mutual TLS")
+	return cmd
+}
+
+// isLoopbackListen reports whether a listen address only accepts local
+// connections. An empty host listens on every interface.
+func isLoopbackListen(listen string) (bool, error) {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false, err
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true, nil
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback(), nil
+}
@@ -0,0 +1,27 @@
+package commands
+
+import "testing"
+
+func TestIsLoopbackListen(t *testing.T) {
+	cases := []struct {
+		listen   string
+		loopback bool
+		wantErr  bool
+	}{
+		{"127.0.0.1:7755", true, false},
+		{"127.0.0.2:7755", true, false},
+		{"[::1]:7755", true, false},
+		{"localhost:7755", true, false},
+		{":7755", false, false},
+		{"0.0.0.0:7755", false, false},
+		{"10.0.0.5:7755", false, false},
+		{"signer.internal:7755", false, false},
+		{"127.0.0.1", false, true},
+	}
+	for _, tc := range cases {
+		loopback, err := isLoopbackListen(tc.listen)
+		if (err != nil) != tc.wantErr || loopback != tc.loopback {
+			t.Errorf("isLoopbackListen(%q) = %v, %v; want %v, error %v", tc.listen, loopback, err, tc.loopback, tc.wantErr)
+		}
+	}
+}
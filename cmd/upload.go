@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
 	"github.com/LumeraProtocol/sdk-go/cascade"
 	"github.com/LumeraProtocol/sdk-go/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 
 	"lumera-ica-client/client"
@@ -21,12 +23,50 @@ func newUploadCmd(app *app) *cobra.Command {
 	var filePath string
 	var actionID string
 	var public bool
+	var skipBalanceCheck bool
+	var batchDir string
+	var maxPerTx int
+	var concurrency int
+	var retries int
+	var noJournal bool
+	var timeouts icaTimeoutFlags
 	cmd := &cobra.Command{
 		Use:   "upload [file]",
 		Short: "Upload file via ICA",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
+			// Batch mode registers a whole directory through shared ICA packets.
+			if strings.TrimSpace(batchDir) != "" {
+				if len(args) > 0 || strings.TrimSpace(filePath) != "" || strings.TrimSpace(actionID) != "" {
+					return fmt.Errorf("--batch cannot be combined with a file or --action-id")
+				}
+				cfg, err := app.loadConfig()
+				if err != nil {
+					return err
+				}
+				if err := timeouts.apply(cmd, cfg); err != nil {
+					return err
+				}
+				ctx, cancel := icaCommandContext(cmd, cfg)
+				defer cancel()
+				opts := batchOptions{
+					dir:              batchDir,
+					maxPerTx:         maxPerTx,
+					concurrency:      concurrency,
+					retries:          retries,
+					public:           public,
+					skipBalanceCheck: skipBalanceCheck,
+				}
+				if !noJournal {
+					opts.journal, err = client.OpenJournal(app.journalPath())
+					if err != nil {
+						return err
+					}
+					defer opts.journal.Close()
+				}
+				return runBatchUpload(ctx, cfg, opts)
+			}
 			// Resolve file path from flag/arg and load config.
 			filePath, err = resolveOptionalArg(filePath, args, "file")
 			if err != nil {
@@ -36,7 +76,10 @@ func newUploadCmd(app *app) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			ctx, cancel := commandContext(cmd)
+			if err := timeouts.apply(cmd, cfg); err != nil {
+				return err
+			}
+			ctx, cancel := icaCommandContext(cmd, cfg)
 			defer cancel()
 
 			// Normalize to an absolute path so downstream logs/metadata are consistent.
@@ -63,13 +106,15 @@ func newUploadCmd(app *app) *cobra.Command {
 					return err
 				}
 				if action.State != types.ActionStatePending {
-					return fmt.Errorf("action %s state is %s; expected %s", action.ID, action.State, types.ActionStatePending)
+					return withCode(codeActionNotPending,
+						fmt.Errorf("action %s state is %s; expected %s", action.ID, action.State, types.ActionStatePending),
+						map[string]any{"action_id": action.ID, "state": action.State})
 				}
 
 				signer := strings.TrimSpace(action.Creator)
 				taskID, err := cascClient.Cascade.UploadToSupernode(ctx, action.ID, absPath, signer)
 				if err != nil {
-					return err
+					return withCode(codeSupernodeUploadFailed, err, map[string]any{"action_id": action.ID, "file": absPath})
 				}
 				payload := map[string]any{
 					"status":            "ok",
@@ -86,44 +131,215 @@ func newUploadCmd(app *app) *cobra.Command {
 				return writeJSON(payload)
 			}
 
+			// Look the file up in the local journal so an interrupted upload resumes
+			// from its last completed step instead of registering a new action.
+			fileHash, err := client.HashFile(absPath)
+			if err != nil {
+				return err
+			}
+			rec := &client.UploadRecord{FileHash: fileHash, FilePath: absPath, Public: public}
+			var journal *client.Journal
+			if !noJournal {
+				journal, err = client.OpenJournal(app.journalPath())
+				if err != nil {
+					return err
+				}
+				defer journal.Close()
+				existing, err := journal.Get(fileHash)
+				if err != nil {
+					return err
+				}
+				// Only an incomplete upload is resumed; a completed or lost one is
+				// replaced, so the same content can be stored as a new action.
+				if existing != nil && existing.Incomplete() && existing.Step() != client.UploadStepStarted {
+					if existing.Public != public {
+						return withCode(codeUsage,
+							fmt.Errorf("journaled upload of %s was registered with --public=%t; rerun with --public=%t to resume it, or --no-journal", absPath, existing.Public, existing.Public),
+							map[string]any{"file": absPath, "tx_hash": existing.TxHash, "action_id": existing.ActionID})
+					}
+					existing.FilePath = absPath
+					rec = existing
+				}
+			}
+			record := func() error {
+				if journal == nil {
+					return nil
+				}
+				return journal.Put(rec)
+			}
+			// Failures carry the file and ICA in the error details. A late ack is
+			// reported as ACK_TIMEOUT; rerunning upload (or resume) keeps waiting.
+			// A registration that can no longer reach Lumera marks the record lost.
+			uploadErr := func(err error) error {
+				if registrationLost(err) {
+					rec.Outcome = client.UploadOutcomeLost
+					if recErr := record(); recErr != nil {
+						return recErr
+					}
+				}
+				return withDetails(err, map[string]any{"file": absPath, "ica_address": rec.ICAAddress})
+			}
+			resumedFrom := rec.Step()
+
 			// Build a controller helper for ICA operations and resolve the ICA address.
 			controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
 			if err != nil {
 				return err
 			}
 			defer controller.Close()
+
+			// Resume: wait for the inclusion of an already submitted registration.
+			if rec.Step() == client.UploadStepSubmitted {
+				ref, err := controller.WaitForPacket(ctx, rec.TxHash)
+				if err != nil {
+					return uploadErr(err)
+				}
+				rec.SetPacket(ref)
+				if err := record(); err != nil {
+					return err
+				}
+			}
+			// Resume: wait for the ack of an already broadcast registration.
+			if rec.Step() == client.UploadStepBroadcast {
+				results, err := controller.AwaitRequestActions(ctx, rec.PacketRef())
+				if err != nil {
+					return uploadErr(err)
+				}
+				if err := rec.SetActionID(results); err != nil {
+					return err
+				}
+				if err := record(); err != nil {
+					return err
+				}
+			}
+			// Resume: the action is registered, only the supernode upload is missing.
+			if rec.ActionID != "" {
+				taskID, err := cascClient.Cascade.UploadToSupernode(ctx, rec.ActionID, absPath, rec.ICAAddress)
+				if err != nil {
+					return withCode(codeSupernodeUploadFailed, err, map[string]any{"action_id": rec.ActionID, "file": absPath})
+				}
+				rec.TaskID = taskID
+				if err := record(); err != nil {
+					return err
+				}
+				return writeJSON(uploadRecordJSON(rec, controller.OwnerAddress(), resumedFrom))
+			}
+
 			icaAddr, err := controller.EnsureICAAddress(ctx)
 			if err != nil {
 				return err
 			}
+			rec.ICAAddress = icaAddr
+			// Top up the ICA first when the [funding] policy requires it.
+			topUp, err := controller.EnsureICAFunded(ctx, icaAddr)
+			if err != nil {
+				return err
+			}
 			// Bridge the request into a controller-side ICA transaction.
+			// The ICA balance is checked against the computed price before broadcasting,
+			// and the journal is updated after the broadcast, the inclusion and the ack.
 			sendFunc := func(ctx context.Context, msg *actiontypes.MsgRequestAction, _ []byte, _ string, _ *cascade.UploadOptions) (*types.ActionResult, error) {
-				return controller.SendRequestAction(ctx, msg)
+				if !skipBalanceCheck {
+					price, err := sdk.ParseCoinNormalized(msg.Price)
+					if err != nil {
+						return nil, fmt.Errorf("parse action price %q: %w", msg.Price, err)
+					}
+					if err := controller.CheckICABalance(ctx, icaAddr, price); err != nil {
+						return nil, err
+					}
+				}
+				txHash, err := controller.BroadcastRequestActions(ctx, []*actiontypes.MsgRequestAction{msg})
+				if err != nil {
+					return nil, err
+				}
+				// Record the tx hash before waiting, so a crash during inclusion resumes it.
+				rec.TxHash = txHash
+				if err := record(); err != nil {
+					return nil, err
+				}
+				ref, err := controller.WaitForPacket(ctx, txHash)
+				if err != nil {
+					return nil, err
+				}
+				rec.SetPacket(ref)
+				if err := record(); err != nil {
+					return nil, err
+				}
+				results, err := controller.AwaitRequestActions(ctx, ref)
+				if err != nil {
+					return nil, err
+				}
+				if err := rec.SetActionID(results); err != nil {
+					return nil, err
+				}
+				if err := record(); err != nil {
+					return nil, err
+				}
+				return results[rec.MsgIndex], nil
 			}
 			// Build and submit the action registration with ICA creator + app pubkey.
 			res, err := cascClient.Cascade.Upload(ctx, icaAddr, nil, absPath,
 				cascade.WithICACreatorAddress(icaAddr),
-				cascade.WithAppPubkey(controller.AppPubkey()),
+				cascade.WithAppPubkey(cascClient.AppPubkey),
 				cascade.WithICASendFunc(sendFunc),
-				cascade.WithPublic(public),
+				cascade.WithPublic(rec.Public),
 			)
+			if err != nil && rec.ActionID != "" {
+				// Registration succeeded; only the supernode upload failed.
+				return withCode(codeSupernodeUploadFailed, err, map[string]any{"action_id": rec.ActionID, "file": absPath})
+			}
 			if err != nil {
+				return uploadErr(err)
+			}
+			rec.TaskID = res.TaskID
+			if err := record(); err != nil {
 				return err
 			}
-			return writeJSON(map[string]any{
-				"status":            "ok",
-				"action_id":         res.ActionID,
-				"tx_hash":           res.TxHash,
-				"task_id":           res.TaskID,
-				"ica_address":       icaAddr,
-				"ica_owner_address": controller.OwnerAddress(),
-				"is_public":         public,
-				"file":              absPath,
-			})
+			payload := uploadRecordJSON(rec, controller.OwnerAddress(), "")
+			if topUp != nil {
+				payload["top_up"] = fundResultJSON(topUp)
+			}
+			return writeJSON(payload)
 		},
 	}
 	cmd.Flags().StringVar(&filePath, "file", "", "Path to file to upload")
 	cmd.Flags().StringVar(&actionID, "action-id", "", "Existing action ID to upload bytes for (skips action registration)")
 	cmd.Flags().BoolVar(&public, "public", false, "Make uploaded file publicly accessible")
+	cmd.Flags().BoolVar(&skipBalanceCheck, "skip-balance-check", false, "Skip the ICA balance preflight against the action price")
+	cmd.Flags().BoolVar(&noJournal, "no-journal", false, "Do not record or resume this upload in the local journal")
+	cmd.Flags().StringVar(&batchDir, "batch", "", "Directory whose files are registered together and uploaded in parallel")
+	cmd.Flags().IntVar(&maxPerTx, "max-per-tx", 50, "Maximum MsgRequestAction messages per ICA MsgSendTx in batch mode")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Maximum parallel supernode uploads in batch mode")
+	cmd.Flags().IntVar(&retries, "retries", 2, "Extra supernode upload attempts per file in batch mode")
+	timeouts.register(cmd)
 	return cmd
 }
+
+// registrationLost reports whether err means a registration tx or packet will
+// never reach Lumera: the controller tx failed or the packet timed out.
+func registrationLost(err error) bool {
+	var timeoutErr *client.PacketTimeoutError
+	var failedErr *client.TxFailedError
+	return errors.As(err, &timeoutErr) || errors.As(err, &failedErr)
+}
+
+// uploadRecordJSON renders a journaled upload; resumedFrom is empty for fresh uploads.
+func uploadRecordJSON(rec *client.UploadRecord, ownerAddr string, resumedFrom client.UploadStep) map[string]any {
+	payload := map[string]any{
+		"status":            "ok",
+		"action_id":         rec.ActionID,
+		"tx_hash":           rec.TxHash,
+		"task_id":           rec.TaskID,
+		"ica_address":       rec.ICAAddress,
+		"ica_owner_address": ownerAddr,
+		"is_public":         rec.Public,
+		"file":              rec.FilePath,
+	}
+	if rec.PacketSequence != 0 {
+		payload["packet_sequence"] = rec.PacketSequence
+	}
+	if resumedFrom != "" && resumedFrom != client.UploadStepStarted {
+		payload["resumed_from"] = resumedFrom
+	}
+	return payload
+}
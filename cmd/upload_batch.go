@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	actiontypes "github.com/LumeraProtocol/lumera/x/action/v1/types"
+	"github.com/LumeraProtocol/sdk-go/cascade"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"lumera-ica-client/client"
+)
+
+// batchItem tracks one file through action registration; rec is its journal
+// record.
+type batchItem struct {
+	File string
+	rec  *client.UploadRecord
+	msg  *actiontypes.MsgRequestAction
+}
+
+// batchOptions carries the upload flags relevant to batch mode.
+type batchOptions struct {
+	dir              string
+	maxPerTx         int
+	concurrency      int
+	retries          int
+	public           bool
+	skipBalanceCheck bool
+	// journal, when set, gets one record per file so resume can finish
+	// registrations left behind by a failed chunk.
+	journal *client.Journal
+}
+
+// runBatchUpload registers every regular file in a directory through as few ICA
+// packets as possible, then uploads the bytes to supernodes through a bounded
+// worker pool, streaming one JSON line per finished file and a final summary.
+// Each file is journaled like a single upload as its chunk progresses.
+func runBatchUpload(ctx context.Context, cfg *client.Config, opts batchOptions) error {
+	if opts.maxPerTx <= 0 {
+		return fmt.Errorf("--max-per-tx must be positive")
+	}
+	files, err := listBatchFiles(opts.dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files found in %s", opts.dir)
+	}
+
+	cascClient, err := client.NewCascadeClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer cascClient.Cascade.Close()
+
+	controller, err := client.NewICAController(ctx, cfg, cascClient.Keyring)
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	icaAddr, err := controller.EnsureICAAddress(ctx)
+	if err != nil {
+		return err
+	}
+	topUp, err := controller.EnsureICAFunded(ctx, icaAddr)
+	if err != nil {
+		return err
+	}
+
+	// Build one MsgRequestAction per file with ICA creator + app pubkey.
+	items := make([]*batchItem, len(files))
+	uploadOpts := &cascade.UploadOptions{
+		Public:            opts.public,
+		ICACreatorAddress: icaAddr,
+		AppPubkey:         cascClient.AppPubkey,
+	}
+	for i, file := range files {
+		msg, _, err := cascClient.Cascade.CreateRequestActionMessage(ctx, icaAddr, file, uploadOpts)
+		if err != nil {
+			return fmt.Errorf("build request for %s: %w", file, err)
+		}
+		fileHash, err := client.HashFile(file)
+		if err != nil {
+			return err
+		}
+		items[i] = &batchItem{
+			File: file,
+			rec:  &client.UploadRecord{FileHash: fileHash, FilePath: file, ICAAddress: icaAddr, Public: opts.public},
+			msg:  msg,
+		}
+	}
+
+	// Register in chunks of --max-per-tx messages per MsgSendTx.
+	var registered []string
+	for start := 0; start < len(items); start += opts.maxPerTx {
+		chunk := items[start:min(start+opts.maxPerTx, len(items))]
+		if !opts.skipBalanceCheck {
+			if err := checkBatchBalance(ctx, controller, icaAddr, chunk); err != nil {
+				return err
+			}
+		}
+		if err := registerBatchChunk(ctx, controller, opts.journal, chunk); err != nil {
+			return withDetails(fmt.Errorf("register files %d-%d: %w", start+1, start+len(chunk), err),
+				map[string]any{"files": batchFiles(chunk), "registered_action_ids": registered})
+		}
+		for _, item := range chunk {
+			registered = append(registered, item.rec.ActionID)
+		}
+	}
+
+	// Upload bytes through the worker pool, streaming one JSON line per file.
+	jobs := make([]client.UploadJob, len(items))
+	for i, item := range items {
+		jobs[i] = client.UploadJob{ActionID: item.rec.ActionID, Path: item.File, Signer: icaAddr}
+	}
+	pool := &client.UploadPool{
+		Uploader:    cascClient.Cascade,
+		Concurrency: opts.concurrency,
+		Retries:     opts.retries,
+	}
+	byActionID := make(map[string]*batchItem, len(items))
+	for _, item := range items {
+		byActionID[item.rec.ActionID] = item
+	}
+	results := pool.Run(ctx, jobs, func(res client.UploadJobResult) {
+		item := byActionID[res.Job.ActionID]
+		line := uploadJobJSON(res)
+		line["tx_hash"] = item.rec.TxHash
+		if res.Err == nil && opts.journal != nil {
+			item.rec.TaskID = res.TaskID
+			if err := opts.journal.Put(item.rec); err != nil {
+				line["journal_error"] = err.Error()
+			}
+		}
+		_ = writeJSONLine(line)
+	})
+
+	failed := 0
+	for _, res := range results {
+		if res.Err != nil {
+			failed++
+		}
+	}
+	status := "ok"
+	if failed > 0 {
+		status = "error"
+	}
+	summary := map[string]any{
+		"status":            status,
+		"ica_address":       icaAddr,
+		"ica_owner_address": controller.OwnerAddress(),
+		"is_public":         opts.public,
+		"total":             len(results),
+		"failed":            failed,
+	}
+	if topUp != nil {
+		summary["top_up"] = fundResultJSON(topUp)
+	}
+	if err := writeJSONLine(summary); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return withCode(codeSupernodeUploadFailed, fmt.Errorf("%d of %d supernode uploads failed", failed, len(results)),
+			map[string]any{"total": len(results), "failed": failed})
+	}
+	return nil
+}
+
+// registerBatchChunk registers a chunk's actions through one MsgSendTx,
+// journaling every file after the broadcast, the inclusion and the ack. A tx
+// that failed or a packet that timed out marks the chunk's records lost.
+func registerBatchChunk(ctx context.Context, controller *client.Controller, journal *client.Journal, chunk []*batchItem) error {
+	record := func(update func(i int, rec *client.UploadRecord) error) error {
+		for i, item := range chunk {
+			if err := update(i, item.rec); err != nil {
+				return err
+			}
+			if journal == nil {
+				continue
+			}
+			if err := journal.Put(item.rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fail := func(err error) error {
+		if registrationLost(err) {
+			if jerr := record(func(_ int, rec *client.UploadRecord) error {
+				rec.Outcome = client.UploadOutcomeLost
+				return nil
+			}); jerr != nil {
+				return errors.Join(err, jerr)
+			}
+		}
+		return err
+	}
+
+	msgs := make([]*actiontypes.MsgRequestAction, len(chunk))
+	for i, item := range chunk {
+		msgs[i] = item.msg
+	}
+	txHash, err := controller.BroadcastRequestActions(ctx, msgs)
+	if err != nil {
+		return err
+	}
+	if err := record(func(i int, rec *client.UploadRecord) error {
+		rec.TxHash, rec.MsgIndex = txHash, i
+		return nil
+	}); err != nil {
+		return err
+	}
+	ref, err := controller.WaitForPacket(ctx, txHash)
+	if err != nil {
+		return fail(err)
+	}
+	if err := record(func(_ int, rec *client.UploadRecord) error {
+		rec.SetPacket(ref)
+		return nil
+	}); err != nil {
+		return err
+	}
+	results, err := controller.AwaitRequestActions(ctx, ref)
+	if err != nil {
+		return fail(err)
+	}
+	if len(results) != len(chunk) {
+		return fmt.Errorf("ack for tx %s has %d action ids; expected %d", txHash, len(results), len(chunk))
+	}
+	return record(func(_ int, rec *client.UploadRecord) error {
+		return rec.SetActionID(results)
+	})
+}
+
+// batchFiles lists the file paths of a chunk.
+func batchFiles(chunk []*batchItem) []string {
+	files := make([]string, len(chunk))
+	for i, item := range chunk {
+		files[i] = item.File
+	}
+	return files
+}
+
+// uploadJobJSON renders a worker pool result as a JSON line payload.
+func uploadJobJSON(res client.UploadJobResult) map[string]any {
+	line := map[string]any{
+		"status":      "ok",
+		"file":        res.Job.Path,
+		"action_id":   res.Job.ActionID,
+		"task_id":     res.TaskID,
+		"attempts":    res.Attempts,
+		"duration_ms": res.Duration.Milliseconds(),
+	}
+	if res.Err != nil {
+		line["status"] = "error"
+		line["error"] = res.Err.Error()
+	}
+	return line
+}
+
+// checkBatchBalance verifies the ICA can cover the summed price of a chunk.
+func checkBatchBalance(ctx context.Context, controller *client.Controller, icaAddr string, chunk []*batchItem) error {
+	total := sdk.NewCoins()
+	for _, item := range chunk {
+		price, err := sdk.ParseCoinNormalized(item.msg.Price)
+		if err != nil {
+			return fmt.Errorf("parse action price %q for %s: %w", item.msg.Price, item.File, err)
+		}
+		total = total.Add(price)
+	}
+	for _, price := range total {
+		if err := controller.CheckICABalance(ctx, icaAddr, price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listBatchFiles returns the absolute paths of regular files in dir, sorted by name.
+func listBatchFiles(dir string) ([]string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		files = append(files, filepath.Join(absDir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
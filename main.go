@@ -2,7 +2,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,8 +12,5 @@ import (
 func main() {
 	// Force Lumera bech32 prefixes for address formatting used in this client.
 	sdk.GetConfig().SetBech32PrefixForAccount("lumera", "lumerapub")
-	if err := commands.NewRootCmd().Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err.Error())
-		os.Exit(1)
-	}
+	os.Exit(commands.Execute())
 }